@@ -6,29 +6,16 @@ import (
 	"testing"
 
 	pc "github.com/macrat/parcon"
+	"github.com/macrat/parcon/internal/gentest"
 )
 
 func generateSimpleList() ([]rune, int) {
-	xs := make([]string, 1000)
-	for i := range xs {
-		xs[i] = fmt.Sprint(i)
-	}
-	return []rune(strings.Join(xs, ",")), len(xs)
+	input, l := gentest.SimpleList()
+	return []rune(input), l
 }
 
 func Benchmark_simpleListWithoutParcon(b *testing.B) {
-	parser := func(input []rune) [][]rune {
-		var result [][]rune
-		var buf []rune
-		for _, x := range input {
-			if x == ',' {
-				result = append(result, buf)
-			} else {
-				buf = append(buf, x)
-			}
-		}
-		return append(result, buf)
-	}
+	parser := gentest.SplitList
 
 	input, l := generateSimpleList()
 	b.SetBytes(int64(len(input)))
@@ -71,6 +58,21 @@ func Benchmark_simpleList(b *testing.B) {
 func Benchmark_jsonParser(b *testing.B) {
 	parser := jsonValue
 
+	input := generateJson()
+	b.SetBytes(int64(len(input)))
+
+	_, _, err := parser.Parse(input, true)
+	if err != nil {
+		b.Fatalf("failed to parse: %s", err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i <= b.N; i++ {
+		parser.Parse(input, true)
+	}
+}
+
+func generateJson() []rune {
 	xs := make([]string, 100)
 	for i := range xs {
 		ys := make([]string, 100)
@@ -79,16 +81,121 @@ func Benchmark_jsonParser(b *testing.B) {
 		}
 		xs[i] = fmt.Sprintf(`"%d": [%s]`, i, strings.Join(ys, ", "))
 	}
-	input := []rune(fmt.Sprintf(`{%s}`, strings.Join(xs, ", ")))
+	return []rune(fmt.Sprintf(`{%s}`, strings.Join(xs, ", ")))
+}
+
+func memoizedJsonParser() pc.Parser[rune, interface{}] {
+	var ref pc.Ref[rune, interface{}]
+	value := pc.Memo[rune, interface{}](&ref)
+
+	array := pc.WithEnclosure(
+		beginArray,
+		pc.SeparatedList(0, valueSeparator, value),
+		endArray,
+	)
+
+	object := pc.Convert(
+		pc.WithEnclosure(
+			beginObject,
+			pc.SeparatedList(0, valueSeparator, pc.Pair(pc.WithSuffix(str, nameSeparator), value)),
+			endObject,
+		),
+		pairsToMap,
+	)
+
+	ref.Set(pc.Named("JSON_VALUE", pc.WithEnclosure(
+		optionalSpaces,
+		pc.Or(
+			null,
+			pc.Convert(str, ToInterface[string]),
+			pc.Convert(number, ToInterface[float64]),
+			pc.Convert(boolean, ToInterface[bool]),
+			pc.Convert(array, ToInterface[[]interface{}]),
+			pc.Convert(object, ToInterface[map[string]interface{}]),
+		),
+		optionalSpaces,
+	)))
+
+	return value
+}
+
+func Benchmark_jsonParserInSession(b *testing.B) {
+	parser := jsonValue
+
+	input := generateJson()
 	b.SetBytes(int64(len(input)))
 
-	_, _, err := parser.Parse(input, true)
-	if err != nil {
-		b.Fatalf("failed to parse: %s", err)
+	b.ResetTimer()
+	for i := 0; i <= b.N; i++ {
+		s := pc.NewSession(input)
+		_, _, err := parser.Parse(s.Input(), true)
+		s.Close()
+		if err != nil {
+			b.Fatalf("failed to parse: %s", err)
+		}
 	}
+}
+
+func Benchmark_jsonParserWithMemo(b *testing.B) {
+	parser := memoizedJsonParser()
+
+	input := generateJson()
+	b.SetBytes(int64(len(input)))
 
 	b.ResetTimer()
 	for i := 0; i <= b.N; i++ {
-		parser.Parse(input, true)
+		s := pc.NewSession(input)
+		_, _, err := parser.Parse(s.Input(), true)
+		s.Close()
+		if err != nil {
+			b.Fatalf("failed to parse: %s", err)
+		}
+	}
+}
+
+// backtrackingParser parses nested parentheses like "((1))+((2))*".
+// The alternatives share the same prefix, so it takes exponential time without memoization.
+func backtrackingParser(memo bool) pc.Parser[rune, []rune] {
+	var ref pc.Ref[rune, []rune]
+	var term pc.Parser[rune, []rune] = &ref
+	if memo {
+		term = pc.Memo(term)
+	}
+
+	ref.Set(pc.Or(
+		pc.MatchOnly(pc.Sequence(pc.Tag("OPEN", []rune("(")), term, pc.Tag("CLOSE", []rune(")")), pc.Tag("PLUS", []rune("+")))),
+		pc.MatchOnly(pc.Sequence(pc.Tag("OPEN", []rune("(")), term, pc.Tag("CLOSE", []rune(")")), pc.Tag("STAR", []rune("*")))),
+		pc.MatchOnly(pc.Sequence(pc.Tag("OPEN", []rune("(")), term, pc.Tag("CLOSE", []rune(")")))),
+		pc.MultiDigits,
+	))
+
+	return term
+}
+
+func benchmarkBacktracking(b *testing.B, memo bool) {
+	parser := backtrackingParser(memo)
+
+	input := []rune(strings.Repeat("(", 8) + "1" + strings.Repeat(")", 8))
+	b.SetBytes(int64(len(input)))
+
+	b.ResetTimer()
+	for i := 0; i <= b.N; i++ {
+		s := pc.NewSession(input)
+		_, remain, err := parser.Parse(s.Input(), true)
+		s.Close()
+		if err != nil {
+			b.Fatalf("failed to parse: %s", err)
+		}
+		if len(remain) != 0 {
+			b.Fatalf("failed to parse: remain %#v", string(remain))
+		}
 	}
 }
+
+func Benchmark_backtracking(b *testing.B) {
+	benchmarkBacktracking(b, false)
+}
+
+func Benchmark_backtrackingWithMemo(b *testing.B) {
+	benchmarkBacktracking(b, true)
+}
@@ -25,8 +25,23 @@ func Optional[I comparable, O any](parser Parser[I, O]) Parser[I, O] {
 }
 
 func (o optionalParser[I, O]) Parse(input []I, verbose bool) (output O, remain []I, err error) {
+	if tracing() {
+		defer trace("Optional", o, input).leave(&remain, &err)
+	}
+
+	s := sessionOf(input)
+	cp := s.checkpoint()
+
+	if err = s.step(); err != nil {
+		return
+	}
+
 	output, remain, err = o.Parser.Parse(input, false)
+	if isFatal(err) {
+		return
+	}
 	if err != nil {
+		s.rollback(cp)
 		return o.Default, input, nil
 	}
 	return
@@ -36,28 +51,62 @@ func (o optionalParser[I, O]) String() string {
 	return fmt.Sprintf("%v", o.Parser)
 }
 
+func (o optionalParser[I, O]) Kind() Kind {
+	return KindOptional
+}
+
+func (o optionalParser[I, O]) Children() []Node {
+	return nodesOf(o.Parser)
+}
+
 type orParser[I comparable, O any] []Parser[I, O]
 
 // Or parses using one of `parsers`, and returns the parsed value that first succeed.
+//
+// If all of `parsers` failed, it reports an error that expects one of `parsers`.
+// ParseAll and other functions that use a Session report the error that occurred at the furthest position instead.
+// The expected things of alternatives that failed at the same position are merged as an ExpectedSet.
 func Or[I comparable, O any](parsers ...Parser[I, O]) Parser[I, O] {
 	return orParser[I, O](parsers)
 }
 
 func (o orParser[I, O]) Parse(input []I, verbose bool) (output O, remain []I, err error) {
+	if tracing() {
+		defer trace("Or", o, input).leave(&remain, &err)
+	}
+
+	s := sessionOf(input)
+	cp := s.checkpoint()
+
 	for _, p := range o {
+		if err = s.step(); err != nil {
+			return
+		}
 		output, remain, err = p.Parse(input, false)
-		if err == nil {
+		if err == nil || isFatal(err) {
 			return
 		}
+		s.rollback(cp)
 	}
-	if verbose {
-		err = ErrInvalidInputVerbose[I]{Expected: o, Input: input}
-	} else {
-		err = ErrInvalidInput
+
+	// The failures of alternatives are not parsed again here, because nested Ors would repeat it for each level.
+	// ParseAll and others find the furthest failure of them once, by tracking failures in explain.
+	if !verbose {
+		return output, remain, ErrInvalidInput
 	}
+	err = invalidInput(o.expected(), input, true)
 	return
 }
 
+// expected returns the alternatives as an ExpectedSet to report them in an error.
+func (o orParser[I, O]) expected() ExpectedSet {
+	expected := make(ExpectedSet, len(o))
+	for i, p := range o {
+		expected[i] = p
+	}
+	return expected
+}
+
 func (o orParser[I, O]) String() string {
 	var ss []string
 	for _, p := range o {
@@ -65,3 +114,15 @@ func (o orParser[I, O]) String() string {
 	}
 	return fmt.Sprintf("one of %s", strings.Join(ss, " "))
 }
+
+func (o orParser[I, O]) Kind() Kind {
+	return KindOr
+}
+
+func (o orParser[I, O]) Children() []Node {
+	ns := make([]Node, len(o))
+	for i, p := range o {
+		ns[i] = NodeOf(p)
+	}
+	return ns
+}
@@ -2,6 +2,7 @@ package parcon_test
 
 import (
 	"fmt"
+	"testing"
 
 	"github.com/macrat/parcon"
 )
@@ -52,5 +53,51 @@ func ExampleOr() {
 	// OUTPUT:
 	// output:"hello" remain:" world" err:<nil>
 	// output:"world" remain:" hello" err:<nil>
-	// err:invalid input: expected one of [HELLO] [WORLD] but got "foo bar"
+	// err:invalid input: expected HELLO or WORLD but got "f"
+}
+
+func ExampleOr_furthestError() {
+	parser := parcon.Or(
+		parcon.Sequence(
+			parcon.TagStr("OPEN", "{"),
+			parcon.Convert(parcon.MultiAlphas, parcon.ToString),
+			parcon.TagStr("COMMA", ","),
+		),
+		parcon.Sequence(
+			parcon.TagStr("OPEN", "{"),
+			parcon.Convert(parcon.MultiAlphas, parcon.ToString),
+			parcon.TagStr("CLOSE", "}"),
+		),
+		parcon.Sequence(
+			parcon.TagStr("OPEN", "["),
+		),
+	)
+
+	// Or itself reports the alternatives, and ParseString reports the furthest failure of them.
+	_, _, err := parser.Parse([]rune("{hello!"), true)
+	fmt.Println(err)
+
+	_, err = parcon.ParseString(parser, "{hello!")
+	fmt.Println(err)
+
+	// OUTPUT:
+	// invalid input: expected [OPEN, ALPHA, COMMA], [OPEN, ALPHA, CLOSE], or [OPEN] but got "{"
+	// invalid input at 1:7: expected COMMA or CLOSE but got "!"
+}
+
+func Test_orParsesOnce(t *testing.T) {
+	calls := 0
+	fail := parcon.Func(func(input []rune, verbose bool) (string, []rune, error) {
+		calls++
+		return "", input, parcon.ErrInvalidInput
+	})
+
+	parser := parcon.Or(parcon.Or(parcon.Or(fail, fail), fail), fail)
+
+	if _, _, err := parser.Parse([]rune("x"), true); err == nil {
+		t.Fatalf("expected an error")
+	}
+	if calls != 4 {
+		t.Errorf("expected 4 calls but got %d", calls)
+	}
 }
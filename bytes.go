@@ -0,0 +1,274 @@
+package parcon
+
+import (
+	"strconv"
+	"unicode/utf8"
+)
+
+// Pre-defined parsers for a single character in UTF-8 encoded []byte.
+var (
+	// A single space or tab character.
+	ByteSingleSpace = ByteOneOf("SPACE", " \t")
+
+	// A single newline character.
+	ByteSingleNewline = ByteOneOf("NEWLINE", "\r\n")
+
+	// A single space, tab, or new line character.
+	ByteSingleSpaceOrNewline = ByteOneOf("SPACE_OR_NEWLINE", " \t\r\n")
+
+	// A single latin alphabet.
+	// This parser is case in-sensitive.
+	ByteSingleAlpha = ByteTakeSingle("ALPHA", isAlpha)
+
+	// A single character of decimal number.
+	ByteSingleDigit = ByteTakeSingle("DIGIT", isDigit)
+
+	// A single character of hex number.
+	ByteSingleHexDigit = ByteTakeSingle("HEX_DIGIT", isHexDigit)
+
+	// A single latin alphabet or a single decimal digit.
+	ByteSingleAlphaNum = ByteTakeSingle("ALPHA_NUM", isAlphaNum)
+)
+
+// Pre-defined parsers for a sequence of characters in UTF-8 encoded []byte.
+var (
+	// A sequence of space or tab characters.
+	ByteMultiSpaces = ByteOneOfList("SPACE", " \t")
+
+	// A sequence of new line characters.
+	ByteMultiNewline = ByteOneOfList("NEWLINE", "\r\n")
+
+	// A sequence of space, tab, or new line characters.
+	ByteMultiSpacesOrNewlines = ByteOneOfList("SPACE_OR_NEWLINE", " \t\r\n")
+
+	// A sequence of latin alphabets.
+	// This parser is case in-sensitive.
+	ByteMultiAlphas = ByteTakeWhile("ALPHA", isAlpha)
+
+	// A sequence of decimal digits.
+	ByteMultiDigits = ByteTakeWhile("DIGIT", isDigit)
+
+	// A sequence of hex number characters.
+	ByteMultiHexDigits = ByteTakeWhile("HEX_DIGIT", isHexDigit)
+
+	// A sequence of latin alphabets or a decimal digits.
+	ByteMultiAlphaNums = ByteTakeWhile("ALPHA_NUM", isAlphaNum)
+)
+
+// BytesToString is a ConvertFunc to convert []byte to string.
+func BytesToString(input []byte) (string, error) {
+	return string(input), nil
+}
+
+// BytesToInt is a ConvertFunc to convert []byte to int.
+func BytesToInt(input []byte) (int, error) {
+	return strconv.Atoi(string(input))
+}
+
+// BytesToFloat is a ConvertFunc to convert []byte to float64.
+func BytesToFloat(input []byte) (float64, error) {
+	return strconv.ParseFloat(string(input), 64)
+}
+
+// ByteTagStr is similar to TagStr, but it parses UTF-8 encoded []byte.
+func ByteTagStr(name string, tag string) Parser[byte, string] {
+	return TagAs(name, []byte(tag), tag)
+}
+
+type byteTakeSingleParser struct {
+	Name string
+	Func func(rune) bool
+}
+
+// ByteTakeSingle is similar to TakeSingle, but it parses a single character in UTF-8 encoded []byte.
+func ByteTakeSingle(name string, fn func(rune) bool) Parser[byte, rune] {
+	return byteTakeSingleParser{name, fn}
+}
+
+func (t byteTakeSingleParser) Parse(input []byte, verbose bool) (output rune, remain []byte, err error) {
+	if tracing() {
+		defer trace("ByteTakeSingle", t, input).leave(&remain, &err)
+	}
+
+	return byteTakeSingle(t.Name, t.Func, input, verbose)
+}
+
+// byteTakeSingle parses a single character that `fn` returns true, from UTF-8 encoded `input`.
+func byteTakeSingle(name string, fn func(rune) bool, input []byte, verbose bool) (output rune, remain []byte, err error) {
+	if !utf8.FullRune(input) {
+		reachEnd(input)
+	}
+
+	c, size := utf8.DecodeRune(input)
+	if size > 0 && fn(c) {
+		return c, input[size:], nil
+	}
+	err = newError(name, input, verbose)
+	return
+}
+
+func (t byteTakeSingleParser) String() string {
+	return t.Name
+}
+
+func (t byteTakeSingleParser) Kind() Kind {
+	return KindTakeSingle
+}
+
+func (t byteTakeSingleParser) Children() []Node {
+	return nil
+}
+
+type byteTakeWhileParser struct {
+	Name string
+	Func func(rune) bool
+}
+
+// ByteTakeWhile is similar to TakeWhile, but it parses a sequence of characters in UTF-8 encoded []byte.
+func ByteTakeWhile(name string, fn func(rune) bool) Parser[byte, []byte] {
+	return byteTakeWhileParser{name, fn}
+}
+
+func (t byteTakeWhileParser) Parse(input []byte, verbose bool) (output []byte, remain []byte, err error) {
+	if tracing() {
+		defer trace("ByteTakeWhile", t, input).leave(&remain, &err)
+	}
+
+	return byteTakeWhile(t.Name, t.Func, input, verbose)
+}
+
+// byteTakeWhile parses one or more characters until `fn` returns false, from UTF-8 encoded `input`.
+func byteTakeWhile(name string, fn func(rune) bool, input []byte, verbose bool) (output []byte, remain []byte, err error) {
+	i := 0
+	for i < len(input) {
+		if len(input)-i < utf8.UTFMax && !utf8.FullRune(input[i:]) {
+			reachEnd(input)
+		}
+		c, size := utf8.DecodeRune(input[i:])
+		if !fn(c) {
+			break
+		}
+		i += size
+	}
+	if i == len(input) {
+		reachEnd(input)
+	}
+
+	if i == 0 {
+		err = newError(name, input, verbose)
+		return
+	}
+	return input[:i], input[i:], nil
+}
+
+func (t byteTakeWhileParser) String() string {
+	return t.Name
+}
+
+func (t byteTakeWhileParser) Kind() Kind {
+	return KindTakeWhile
+}
+
+func (t byteTakeWhileParser) Children() []Node {
+	return nil
+}
+
+// byteCharSet is a set of characters for ByteOneOf, ByteNoneOf, and their variants.
+type byteCharSet struct {
+	Name   string
+	List   []rune
+	Negate bool
+}
+
+// match checks if `c` is matched to the set.
+func (b byteCharSet) match(c rune) bool {
+	return contains(b.List, c) != b.Negate
+}
+
+func (b byteCharSet) String() string {
+	return b.Name
+}
+
+func (b byteCharSet) Children() []Node {
+	return nil
+}
+
+// Literal returns the characters in the set as []rune, as the same as OneOf or NoneOf for []rune.
+func (b byteCharSet) Literal() any {
+	return b.List
+}
+
+type byteOneOfParser struct {
+	byteCharSet
+}
+
+// ByteOneOf is similar to OneOf, but it parses a single character that listed in `list`, from UTF-8 encoded []byte.
+func ByteOneOf(name string, list string) Parser[byte, rune] {
+	return byteOneOfParser{byteCharSet{name, []rune(list), false}}
+}
+
+func (o byteOneOfParser) Parse(input []byte, verbose bool) (output rune, remain []byte, err error) {
+	if tracing() {
+		defer trace("ByteOneOf", o, input).leave(&remain, &err)
+	}
+
+	return byteTakeSingle(o.Name, o.match, input, verbose)
+}
+
+func (o byteOneOfParser) Kind() Kind {
+	if o.Negate {
+		return KindNoneOf
+	}
+	return KindOneOf
+}
+
+type byteOneOfListParser struct {
+	byteCharSet
+}
+
+// ByteOneOfList is similar to OneOfList, but it parses one or more characters that listed in `list`, from UTF-8 encoded []byte.
+func ByteOneOfList(name string, list string) Parser[byte, []byte] {
+	return byteOneOfListParser{byteCharSet{name, []rune(list), false}}
+}
+
+func (o byteOneOfListParser) Parse(input []byte, verbose bool) (output []byte, remain []byte, err error) {
+	if tracing() {
+		defer trace("ByteOneOfList", o, input).leave(&remain, &err)
+	}
+
+	return byteTakeWhile(o.Name, o.match, input, verbose)
+}
+
+func (o byteOneOfListParser) Kind() Kind {
+	if o.Negate {
+		return KindNoneOfList
+	}
+	return KindOneOfList
+}
+
+// ByteOneOfStr is similar to OneOfStr, but it parses UTF-8 encoded []byte.
+func ByteOneOfStr(name string, list string) Parser[byte, string] {
+	return Convert(ByteOneOfList(name, list), BytesToString)
+}
+
+// ByteNoneOf is similar to NoneOf, but it parses a single character that NOT listed in `list`, from UTF-8 encoded []byte.
+func ByteNoneOf(name string, list string) Parser[byte, rune] {
+	return byteOneOfParser{byteCharSet{name, []rune(list), true}}
+}
+
+// ByteNoneOfList is similar to NoneOfList, but it parses one or more characters that NOT listed in `list`, from UTF-8 encoded []byte.
+func ByteNoneOfList(name string, list string) Parser[byte, []byte] {
+	return byteOneOfListParser{byteCharSet{name, []rune(list), true}}
+}
+
+// ByteNoneOfStr is similar to NoneOfStr, but it parses UTF-8 encoded []byte.
+func ByteNoneOfStr(name string, list string) Parser[byte, string] {
+	return Convert(ByteNoneOfList(name, list), BytesToString)
+}
+
+// ByteAnything is similar to Anything, but it parses any single character in UTF-8 encoded []byte.
+func ByteAnything() Parser[byte, rune] {
+	return ByteTakeSingle("ANYTHING", func(rune) bool {
+		return true
+	})
+}
@@ -0,0 +1,132 @@
+package parcon_test
+
+import (
+	"fmt"
+	"testing"
+	"unicode"
+
+	"github.com/macrat/parcon"
+)
+
+func ExampleByteTagStr() {
+	parser := parcon.ByteTagStr("HELLO", "hello")
+
+	output, remain, err := parser.Parse([]byte("hello world"), true)
+	fmt.Printf("output:%#v remain:%#v err:%v\n", output, string(remain), err)
+
+	// OUTPUT:
+	// output:"hello" remain:" world" err:<nil>
+}
+
+func ExampleByteTakeSingle() {
+	parser := parcon.ByteTakeSingle("LETTER", unicode.IsLetter)
+
+	output, remain, err := parser.Parse([]byte("日本語"), true)
+	fmt.Printf("output:%#v remain:%#v err:%v\n", string(output), string(remain), err)
+
+	// OUTPUT:
+	// output:"日" remain:"本語" err:<nil>
+}
+
+func ExampleByteTakeWhile() {
+	parser := parcon.ByteTakeWhile("LETTERS", unicode.IsLetter)
+
+	output, remain, err := parser.Parse([]byte("こんにちは 世界"), true)
+	fmt.Printf("output:%#v remain:%#v err:%v\n", string(output), string(remain), err)
+
+	// OUTPUT:
+	// output:"こんにちは" remain:" 世界" err:<nil>
+}
+
+func ExampleByteOneOfStr() {
+	parser := parcon.ByteOneOfStr("DIGITS", "0123456789")
+
+	output, remain, err := parser.Parse([]byte("123 hello"), true)
+	fmt.Printf("output:%#v remain:%#v err:%v\n", output, string(remain), err)
+
+	// OUTPUT:
+	// output:"123" remain:" hello" err:<nil>
+}
+
+func ExampleByteNoneOfStr() {
+	parser := parcon.ByteNoneOfStr("NOT_DIGITS", "0123456789")
+
+	output, remain, err := parser.Parse([]byte("こんにちは123"), true)
+	fmt.Printf("output:%#v remain:%#v err:%v\n", output, string(remain), err)
+
+	// OUTPUT:
+	// output:"こんにちは" remain:"123" err:<nil>
+}
+
+func ExamplePEG_bytes() {
+	parser := parcon.Named("assign", parcon.Sequence(
+		parcon.Named("name", parcon.ByteOneOfStr("LETTERS", "abc")),
+		parcon.Convert(parcon.ByteOneOf("EQUAL", "="), func(r rune) (string, error) { return string(r), nil }),
+		parcon.Named("value", parcon.ByteNoneOfStr("NOT_SPACES", " \t")),
+	))
+
+	fmt.Print(parcon.PEG(parser))
+	fmt.Print(parcon.EBNF(parcon.ByteNoneOf("NOT_DIGIT", "0123456789")))
+
+	// OUTPUT:
+	// assign <- name "=" value
+	// name <- [abc]+
+	// value <- (![ \t] .)+
+	// ROOT = ? any character except "0" | "1" | "2" | "3" | "4" | "5" | "6" | "7" | "8" | "9" ? ;
+}
+
+func ExampleParseString_bytes() {
+	word := parcon.WithSpan(parcon.Convert(parcon.ByteTakeWhile("WORD", unicode.IsLetter), parcon.BytesToString))
+	parser := parcon.SeparatedList(0, parcon.ByteMultiSpaces, word)
+
+	input := "日本語 and English"
+
+	output, err := parcon.ParseString(parser, input)
+	if err != nil {
+		panic(err)
+	}
+	for _, x := range output {
+		fmt.Printf("%s: %v %#v\n", x.Value, x.Span, input[x.Span.Start.Offset:x.Span.End.Offset])
+	}
+
+	_, err = parcon.ParseString(parser, "日本語 and 123")
+	fmt.Println(err)
+
+	// OUTPUT:
+	// 日本語: 1:1-1:4 "日本語"
+	// and: 1:5-1:8 "and"
+	// English: 1:9-1:16 "English"
+	// invalid input at 1:9: expected WORD but got "1"
+}
+
+func Fuzz_bytes(f *testing.F) {
+	f.Add("hello world")
+	f.Add("123abc")
+	f.Add("日本語 テキスト")
+	f.Add("\xff\xfe invalid")
+
+	runeParser := parcon.Pair(
+		parcon.Convert(parcon.Optional(parcon.MultiAlphaNums), parcon.ToString),
+		parcon.Optional(parcon.OneOfStr("SPACES", " \t")),
+	)
+	byteParser := parcon.Pair(
+		parcon.Convert(parcon.Optional(parcon.ByteMultiAlphaNums), parcon.BytesToString),
+		parcon.Optional(parcon.ByteOneOfStr("SPACES", " \t")),
+	)
+
+	f.Fuzz(func(t *testing.T, input string) {
+		want, wantRemain, wantErr := runeParser.Parse([]rune(input), true)
+		got, gotRemain, gotErr := byteParser.Parse([]byte(input), true)
+
+		if (wantErr == nil) != (gotErr == nil) {
+			t.Fatalf("unexpected error\nwant: %v\n got: %v", wantErr, gotErr)
+		}
+		if want != got {
+			t.Errorf("unexpected output\nwant: %#v\n got: %#v", want, got)
+		}
+		// Invalid UTF-8 sequences are converted to utf8.RuneError in []rune.
+		if string(wantRemain) != string([]rune(string(gotRemain))) {
+			t.Errorf("unexpected remain\nwant: %#v\n got: %#v", string(wantRemain), string(gotRemain))
+		}
+	})
+}
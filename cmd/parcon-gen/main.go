@@ -0,0 +1,62 @@
+// Command parcon-gen generates a Go parser from a PEG grammar file.
+//
+// The grammar is the same as parcon.CompilePEG, and the generated code is described in parcon.GenerateGo.
+//
+// Usage:
+//
+//	parcon-gen [-package NAME] [-name NAME] [-o FILE] GRAMMAR_FILE
+//
+// It is designed to be used with go:generate like below.
+//
+//	//go:generate go run github.com/macrat/parcon/cmd/parcon-gen -name JSON -o json_gen.go json.peg
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/macrat/parcon"
+)
+
+func main() {
+	pkg := flag.String("package", os.Getenv("GOPACKAGE"), "package name of the generated code. (default $GOPACKAGE)")
+	name := flag.String("name", "Grammar", "name of the generated parser. The function is named Parse<name>.")
+	output := flag.String("o", "", "output file. (default stdout)")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [options] GRAMMAR_FILE\n\nOptions:\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *pkg == "" {
+		*pkg = "main"
+	}
+
+	if err := run(flag.Arg(0), *output, *pkg, *name); err != nil {
+		fmt.Fprintln(os.Stderr, "parcon-gen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(input, output, pkg, name string) error {
+	grammar, err := os.ReadFile(input)
+	if err != nil {
+		return err
+	}
+
+	src, err := parcon.GenerateGo(string(grammar), pkg, name)
+	if err != nil {
+		return fmt.Errorf("%s: %w", input, err)
+	}
+
+	if output == "" {
+		_, err = os.Stdout.Write(src)
+		return err
+	}
+	return os.WriteFile(output, src, 0o644)
+}
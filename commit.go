@@ -0,0 +1,84 @@
+package parcon
+
+import (
+	"fmt"
+)
+
+// ErrCommitted is an error that occurred after Commit.
+//
+// Parsers that backtrack, like Or, Optional, or Many, do not try other choices if they got this error.
+// They just report this error to the caller as is.
+type ErrCommitted struct {
+	Err error
+}
+
+// Unwrap returns the original error.
+func (e ErrCommitted) Unwrap() error {
+	return e.Err
+}
+
+// Error returns the message of the original error.
+func (e ErrCommitted) Error() string {
+	return e.Err.Error()
+}
+
+// isFatal checks if `err` should stop backtracking.
+func isFatal(err error) bool {
+	_, ok := err.(ErrCommitted)
+	return ok || isAborted(err)
+}
+
+type commitParser[I comparable, O any] struct {
+	Parser Parser[I, O]
+}
+
+// Commit parses input using the given `parser`, and makes the failure of it final. It is also known as cut.
+//
+// Use Commit after a part that decides which alternative is chosen, like below.
+// When the input starts with "{", errors in the object body are reported at the precise position, instead of Or reports that the input is not an array nor an object.
+//
+//	parcon.Or(
+//		parcon.WithPrefix(parcon.TagStr("BEGIN_ARRAY", "["), parcon.Commit(arrayBody)),
+//		parcon.WithPrefix(parcon.TagStr("BEGIN_OBJECT", "{"), parcon.Commit(objectBody)),
+//	)
+//
+// The error is wrapped with ErrCommitted, and it always has detail even if not in verbose mode.
+func Commit[I comparable, O any](parser Parser[I, O]) Parser[I, O] {
+	return commitParser[I, O]{parser}
+}
+
+func (c commitParser[I, O]) Parse(input []I, verbose bool) (output O, remain []I, err error) {
+	if tracing() {
+		defer trace("Commit", c, input).leave(&remain, &err)
+	}
+
+	s := sessionOf(input)
+	cp := s.checkpoint()
+
+	output, remain, err = c.Parser.Parse(input, verbose)
+	if err == nil || isFatal(err) {
+		return
+	}
+
+	if !verbose {
+		// Parse again to get the detail of the error, because committed error is final.
+		s.rollback(cp)
+		output, remain, err = c.Parser.Parse(input, true)
+	}
+	err = s.explain(err, cp, func() {
+		c.Parser.Parse(input, true)
+	})
+	return output, remain, ErrCommitted{err}
+}
+
+func (c commitParser[I, O]) String() string {
+	return fmt.Sprint(c.Parser)
+}
+
+func (c commitParser[I, O]) Kind() Kind {
+	return KindCommit
+}
+
+func (c commitParser[I, O]) Children() []Node {
+	return nodesOf(c.Parser)
+}
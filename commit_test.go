@@ -0,0 +1,47 @@
+package parcon_test
+
+import (
+	"fmt"
+
+	"github.com/macrat/parcon"
+)
+
+func ExampleCommit() {
+	item := func(commit bool) parcon.Parser[rune, string] {
+		body := parcon.WithSuffix(parcon.Convert(parcon.MultiDigits, parcon.ToString), parcon.TagStr("CLOSE", ")"))
+		if commit {
+			body = parcon.Commit(body)
+		}
+		return parcon.WithPrefix(parcon.TagStr("OPEN", "("), body)
+	}
+
+	output, remain, err := parcon.Many(0, item(false)).Parse([]rune("(1)(2(3)"), true)
+	fmt.Printf("without commit: output:%#v remain:%#v err:%v\n", output, string(remain), err)
+
+	_, _, err = parcon.Many(0, item(true)).Parse([]rune("(1)(2(3)"), false)
+	fmt.Printf("with commit: err:%v\n", err)
+
+	// OUTPUT:
+	// without commit: output:[]string{"1"} remain:"(2(3)" err:<nil>
+	// with commit: err:invalid input: expected CLOSE but got "("
+}
+
+func ExampleCommit_recover() {
+	newline := parcon.TagStr("NEWLINE", "\n")
+	line := parcon.Recover(parcon.WithSuffix(parcon.Convert(parcon.MultiDigits, parcon.ToInt), newline), newline, 0)
+	parser := parcon.Commit(parcon.WithSuffix(parcon.Many(0, line), parcon.TagStr("END", "end")))
+
+	output, err := parcon.ParseString(parser, "1\nx\n2\nfin", parcon.Verbose(false))
+	fmt.Printf("output:%#v\n", output)
+
+	if errs, ok := err.(parcon.ErrorList); ok {
+		for _, e := range errs {
+			fmt.Println(e)
+		}
+	}
+
+	// OUTPUT:
+	// output:[]int(nil)
+	// invalid input at 2:1: expected DIGIT but got "x"
+	// invalid input at 4:1: expected DIGIT or END but got "f"
+}
@@ -21,6 +21,10 @@ func Convert[I comparable, O1, O2 any](parser Parser[I, O1], fn ConvertFunc[O1,
 }
 
 func (c converter[I, O1, O2]) Parse(input []I, verbose bool) (output O2, remain []I, err error) {
+	if tracing() {
+		defer trace("Convert", c, input).leave(&remain, &err)
+	}
+
 	var o O1
 	o, remain, err = c.Parser.Parse(input, verbose)
 	if err != nil {
@@ -35,6 +39,14 @@ func (c converter[I, O1, O2]) String() string {
 	return fmt.Sprint(c.Parser)
 }
 
+func (c converter[I, O1, O2]) Kind() Kind {
+	return KindConvert
+}
+
+func (c converter[I, O1, O2]) Children() []Node {
+	return nodesOf(c.Parser)
+}
+
 type matchOnly[I comparable, O any] []Parser[I, O]
 
 // MatchOnly parses the input with the given `parsers`, but returns a range of input string that parsed as is.
@@ -43,6 +55,10 @@ func MatchOnly[I comparable, O any](parsers ...Parser[I, O]) Parser[I, []I] {
 }
 
 func (m matchOnly[I, O]) Parse(input []I, verbose bool) (output []I, remain []I, err error) {
+	if tracing() {
+		defer trace("MatchOnly", m, input).leave(&remain, &err)
+	}
+
 	remain = input
 	for _, p := range m {
 		_, remain, err = p.Parse(remain, verbose)
@@ -66,6 +82,18 @@ func (m matchOnly[I, O]) String() string {
 	}
 }
 
+func (m matchOnly[I, O]) Kind() Kind {
+	return KindMatchOnly
+}
+
+func (m matchOnly[I, O]) Children() []Node {
+	ns := make([]Node, len(m))
+	for i, p := range m {
+		ns[i] = NodeOf(p)
+	}
+	return ns
+}
+
 type replace[I comparable, O1, O2 any] struct {
 	Parser Parser[I, O1]
 	Value  O2
@@ -80,7 +108,19 @@ func (r replace[I, O1, O2]) String() string {
 	return fmt.Sprint(r.Parser)
 }
 
+func (r replace[I, O1, O2]) Kind() Kind {
+	return KindReplace
+}
+
+func (r replace[I, O1, O2]) Children() []Node {
+	return nodesOf(r.Parser)
+}
+
 func (r replace[I, O1, O2]) Parse(input []I, verbose bool) (output O2, remain []I, err error) {
+	if tracing() {
+		defer trace("Replace", r, input).leave(&remain, &err)
+	}
+
 	_, remain, err = r.Parser.Parse(input, verbose)
 	if err != nil {
 		return
@@ -40,7 +40,7 @@ func ExampleMatchOnly() {
 	// OUTPUT:
 	// output:"hello123" remain:"" err:<nil>
 	// output:"hello" remain:"" err:<nil>
-	// err:invalid input: expected ALPHA but got "123hello"
+	// err:invalid input: expected ALPHA but got "1"
 }
 
 func ExampleReplace() {
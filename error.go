@@ -3,6 +3,9 @@ package parcon
 import (
 	"errors"
 	"fmt"
+	"strings"
+	"sync/atomic"
+	"unicode/utf8"
 )
 
 // ErrInvalidInput is a error when the parser found unexpected input.
@@ -12,19 +15,243 @@ var ErrInvalidInput = errors.New("invalid input")
 type ErrInvalidInputVerbose[I comparable] struct {
 	Expected any
 	Input    []I
+
+	// Position is the position where the error occurred.
+	// It is nil if the parser is not used in a Session.
+	Position *Position
+
+	// Err is the original error if this error is relabeled by Named.
+	Err error
+}
+
+// newError makes a error of unexpected `input`, and records it as a failure if the Session is tracking the furthest failure.
+// It returns ErrInvalidInputVerbose if `verbose` is true, otherwise returns ErrInvalidInput.
+//
+// The `expected` is a type parameter instead of any, to avoid allocation in non-verbose mode.
+func newError[I comparable, E any](expected E, input []I, verbose bool) error {
+	if len(input) == 0 {
+		reachEnd(input)
+	}
+	if atomic.LoadInt32(&activeTrackers) != 0 {
+		trackFailure(expected, input)
+	}
+	return invalidInput(expected, input, verbose)
 }
 
-// Unwrap always returns ErrInvalidInput.
+// invalidInput makes a error of unexpected `input` as the same as newError, but it does not record the failure.
+// Combinators that report the failures of their children, like Or, use it.
+func invalidInput[I comparable, E any](expected E, input []I, verbose bool) error {
+	if !verbose {
+		return ErrInvalidInput
+	}
+
+	err := ErrInvalidInputVerbose[I]{Expected: expected, Input: input}
+	if s := sessionOf(input); s != nil {
+		pos := s.Position(input)
+		err.Position = &pos
+	}
+	return err
+}
+
+// Unwrap returns the original error if this error is relabeled by Named, otherwise returns ErrInvalidInput.
 func (e ErrInvalidInputVerbose[I]) Unwrap() error {
+	if e.Err != nil {
+		return e.Err
+	}
 	return ErrInvalidInput
 }
 
 // Error returns human readable string.
 func (e ErrInvalidInputVerbose[I]) Error() string {
-	switch i := any(e.Input).(type) {
-	case []rune:
-		return fmt.Sprintf("invalid input: expected %v but got %#v", e.Expected, string(i))
+	at := ""
+	if e.Position != nil {
+		at = fmt.Sprintf(" at %v", *e.Position)
+	}
+
+	got := "end of input"
+	if len(e.Input) > 0 {
+		switch i := any(e.Input).(type) {
+		case []rune:
+			got = fmt.Sprintf("%#v", string(i[0]))
+		case []byte:
+			if e.Position != nil && e.Position.Line > 0 {
+				// The input is UTF-8 encoded text.
+				c, _ := utf8.DecodeRune(i)
+				got = fmt.Sprintf("%#v", string(c))
+			} else {
+				got = fmt.Sprintf("%v", i[0])
+			}
+		default:
+			got = fmt.Sprintf("%v", e.Input[0])
+		}
+	}
+
+	return fmt.Sprintf("invalid input%s: expected %v but got %s", at, e.Expected, got)
+}
+
+// ExpectedSet is a set of things that expected at the same position.
+// It is used as ErrInvalidInputVerbose.Expected when some alternatives failed at the same position.
+type ExpectedSet []any
+
+// String returns human readable string like "A, B, or C".
+func (e ExpectedSet) String() string {
+	ss := make([]string, len(e))
+	for i, x := range e {
+		ss[i] = fmt.Sprint(x)
+	}
+
+	switch len(ss) {
+	case 0:
+		return "nothing"
+	case 1:
+		return ss[0]
+	case 2:
+		return ss[0] + " or " + ss[1]
 	default:
-		return fmt.Sprintf("invalid input: expected %v but got %v", e.Expected, e.Input)
+		return strings.Join(ss[:len(ss)-1], ", ") + ", or " + ss[len(ss)-1]
+	}
+}
+
+// add adds `expected` into the set if it is not in the set yet.
+func (e ExpectedSet) add(expected any) ExpectedSet {
+	if xs, ok := expected.(ExpectedSet); ok {
+		for _, x := range xs {
+			e = e.add(x)
+		}
+		return e
+	}
+
+	s := fmt.Sprint(expected)
+	for _, x := range e {
+		if fmt.Sprint(x) == s {
+			return e
+		}
+	}
+	return append(e, expected)
+}
+
+// mapVerboseErrors replaces each ErrInvalidInputVerbose in `err` with the result of `fn`.
+// It also replaces errors that wrapped by Named, Commit, or ErrorList.
+func mapVerboseErrors[I comparable](err error, fn func(ErrInvalidInputVerbose[I]) ErrInvalidInputVerbose[I]) error {
+	switch e := err.(type) {
+	case ErrInvalidInputVerbose[I]:
+		if e.Err != nil {
+			e.Err = mapVerboseErrors(e.Err, fn)
+		}
+		return fn(e)
+	case ErrCommitted:
+		return ErrCommitted{mapVerboseErrors(e.Err, fn)}
+	case ErrorList:
+		errs := make(ErrorList, len(e))
+		for i, x := range e {
+			errs[i] = mapVerboseErrors(x, fn)
+		}
+		return errs
+	default:
+		return err
+	}
+}
+
+// activeTrackers is the number of sessions that are tracking the furthest failure.
+var activeTrackers int32
+
+// failure is a position where parsers failed, and things that expected at there.
+type failure[I comparable] struct {
+	Input    []I
+	Expected ExpectedSet
+}
+
+// at checks if the failure is at the beginning of `input`.
+func (f failure[I]) at(input []I) bool {
+	return f.Input != nil && len(f.Input) == len(input)
+}
+
+// trackFailure records a failure into the Session of `input`, if the Session is tracking the furthest failure.
+func trackFailure[I comparable, E any](expected E, input []I) {
+	s := sessionOf(input)
+	if s == nil || !s.tracking {
+		return
+	}
+
+	switch {
+	case s.furthest.Input != nil && len(input) > len(s.furthest.Input):
+		return
+	case s.furthest.Input == nil || len(input) < len(s.furthest.Input):
+		s.furthest = failure[I]{Input: input}
+	}
+	s.furthest.Expected = s.furthest.Expected.add(expected)
+}
+
+// explain finds the furthest failure by calling `parse` again from the checkpoint `from`, and merges it into `err`.
+//
+// Backtracking parsers like Many, Optional, or Or discard failures of their children, but the furthest one is usually the most helpful to users.
+// For example, when "[1, 2 3]" is parsed as a list, the furthest failure says that "," or "]" is expected at " 3".
+// So ParseAll, Stream, iterators, and parsers that make a final error like Commit parse the input again in verbose mode with tracking failures, if they failed.
+// It does nothing if `err` is not a verbose error, or if the Session is nil.
+func (s *Session[I]) explain(err error, from checkpoint, parse func()) error {
+	if s == nil {
+		return err
+	}
+	committed, isCommitted := err.(ErrCommitted)
+	if isCommitted {
+		err = committed.Err
+	}
+	e, ok := err.(ErrInvalidInputVerbose[I])
+	if !ok {
+		if isCommitted {
+			return committed
+		}
+		return err
+	}
+
+	// Parse again without side effects like recovered errors or events of Tracer.
+	// The memo table is cleared, because failures in memoized parsers have to be tracked again.
+	// If it is called while explaining another error, the outer one already tracked the failures of this parse.
+	nested := s.tracking
+	errors, current, tracer, outer := append(ErrorList(nil), s.errors...), s.checkpoint(), s.config.tracer, s.furthest
+	s.rollback(from)
+	s.furthest = failure[I]{}
+	if !nested {
+		s.config.tracer = nil
+		s.memo, s.growing = nil, nil
+		s.steps = 0
+		s.tracking = true
+		atomic.AddInt32(&activeTrackers, 1)
+	}
+
+	parse()
+
+	f := s.furthest
+	if !nested {
+		atomic.AddInt32(&activeTrackers, -1)
+		s.tracking = false
+	} else {
+		s.furthest = outer
+	}
+	s.rollback(current)
+	s.errors, s.config.tracer = errors, tracer
+
+	var expected ExpectedSet
+	switch {
+	case f.Input == nil || len(f.Input) > len(e.Input):
+		// The error is already the furthest.
+		expected = ExpectedSet{e.Expected}
+	case len(f.Input) < len(e.Input):
+		pos := s.Position(f.Input)
+		e = ErrInvalidInputVerbose[I]{Input: f.Input, Position: &pos}
+		expected = f.Expected
+	default:
+		// All parsers that failed at there are already tracked, and the error may be a summary of them like the one of Or.
+		expected = f.Expected
+	}
+	if len(expected) == 1 {
+		e.Expected = expected[0]
+	} else {
+		e.Expected = expected
+	}
+
+	if isCommitted {
+		return ErrCommitted{e}
 	}
+	return e
 }
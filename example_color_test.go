@@ -36,14 +36,7 @@ var ColorParser = pc.Convert(
 )
 
 func ParseColor(input string) (Color, error) {
-	output, remain, err := ColorParser.Parse([]rune(input), true)
-	if err != nil {
-		return Color{}, err
-	}
-	if len(remain) != 0 {
-		return Color{}, fmt.Errorf("found extra string: %#v", string(remain))
-	}
-	return output, nil
+	return pc.ParseString(ColorParser, input)
 }
 
 func Example_cssColor() {
@@ -116,67 +116,51 @@ var (
 		jsonValue,
 	)
 
-	jsonValue = pc.Named("JSON_VALUE", pc.WithEnclosure(
+	array = pc.Named("ARRAY", pc.WithEnclosure(
+		beginArray,
+		pc.SeparatedList(0, valueSeparator, jsonValue),
+		endArray,
+	))
+
+	object = pc.Named("OBJECT", pc.Convert(
+		pc.WithEnclosure(
+			beginObject,
+			pc.SeparatedList(0, valueSeparator, keyValuePair),
+			endObject,
+		),
+		pairsToMap,
+	))
+
+	jsonValueRef pc.Ref[rune, interface{}]
+	jsonValue    pc.Parser[rune, interface{}] = &jsonValueRef
+)
+
+func init() {
+	jsonValueRef.Set(pc.Named("JSON_VALUE", pc.WithEnclosure(
 		optionalSpaces,
 		pc.Or(
 			null,
 			pc.Convert(str, ToInterface[string]),
 			pc.Convert(number, ToInterface[float64]),
 			pc.Convert(boolean, ToInterface[bool]),
-			pc.Convert[rune, []interface{}](Array{}, ToInterface[[]interface{}]),
-			pc.Convert[rune, map[string]interface{}](Object{}, ToInterface[map[string]interface{}]),
+			pc.Convert(array, ToInterface[[]interface{}]),
+			pc.Convert(object, ToInterface[map[string]interface{}]),
 		),
 		optionalSpaces,
-	))
-)
-
-type Array struct{}
-
-func (a Array) String() string {
-	return "ARRAY"
+	)))
 }
 
-func (a Array) Parse(input []rune, verbose bool) ([]interface{}, []rune, error) {
-	return pc.WithEnclosure(
-		beginArray,
-		pc.SeparatedList(0, valueSeparator, jsonValue),
-		endArray,
-	).Parse(input, verbose)
-}
-
-type Object struct{}
-
-func (o Object) String() string {
-	return "OBJECT"
-}
-
-func (o Object) Parse(input []rune, verbose bool) (map[string]interface{}, []rune, error) {
-	xs, remain, err := pc.WithEnclosure(
-		beginObject,
-		pc.SeparatedList(0, valueSeparator, keyValuePair),
-		endObject,
-	).Parse(input, verbose)
-	if err != nil {
-		return nil, nil, err
-	}
-
+func pairsToMap(xs []pc.PairValue[string, interface{}]) (map[string]interface{}, error) {
 	result := make(map[string]interface{})
 	for _, x := range xs {
 		result[x.First] = x.Second
 	}
-	return result, remain, nil
+	return result, nil
 }
 
 // Parse JSON that defined in RFC8259
 func ParseJson(s string) (interface{}, error) {
-	output, remain, err := jsonValue.Parse([]rune(s), true)
-	if err != nil {
-		return nil, err
-	}
-	if len(remain) != 0 {
-		return nil, fmt.Errorf("found extra string: %s", string(remain))
-	}
-	return output, nil
+	return pc.ParseString(jsonValue, s)
 }
 
 func Example_json() {
@@ -20,14 +20,7 @@ var QuotedString = pc.WithEnclosure(
 )
 
 func ParseQuotedString(input string) (string, error) {
-	output, remain, err := QuotedString.Parse([]rune(input), true)
-	if err != nil {
-		return "", err
-	}
-	if len(remain) != 0 {
-		return "", fmt.Errorf("found extra string: %#v", string(remain))
-	}
-	return output, nil
+	return pc.ParseString(QuotedString, input)
 }
 
 func Example_quotedStringWithEscape() {
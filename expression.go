@@ -0,0 +1,289 @@
+package parcon
+
+import (
+	"fmt"
+)
+
+type operatorKind int
+
+const (
+	prefixOperator operatorKind = iota
+	postfixOperator
+	infixLeftOperator
+	infixRightOperator
+	infixNonAssocOperator
+)
+
+// Operator is an operator for Expression.
+// Please use Prefix, Postfix, InfixLeft, InfixRight, or InfixNonAssoc to make it.
+type Operator[I comparable, O any] struct {
+	kind   operatorKind
+	unary  Parser[I, func(O) (O, error)]
+	binary Parser[I, func(O, O) (O, error)]
+}
+
+// Prefix makes a prefix operator like `-x`.
+//
+// The `op` parses the operator, and the `fold` makes a value from the output of `op` and the operand.
+func Prefix[I comparable, O, Op any](op Parser[I, Op], fold func(op Op, x O) (O, error)) Operator[I, O] {
+	return Operator[I, O]{
+		kind: prefixOperator,
+		unary: Convert(op, func(o Op) (func(O) (O, error), error) {
+			return func(x O) (O, error) {
+				return fold(o, x)
+			}, nil
+		}),
+	}
+}
+
+// Postfix makes a postfix operator like `x!`.
+//
+// The `op` parses the operator, and the `fold` makes a value from the operand and the output of `op`.
+func Postfix[I comparable, O, Op any](op Parser[I, Op], fold func(x O, op Op) (O, error)) Operator[I, O] {
+	return Operator[I, O]{
+		kind: postfixOperator,
+		unary: Convert(op, func(o Op) (func(O) (O, error), error) {
+			return func(x O) (O, error) {
+				return fold(x, o)
+			}, nil
+		}),
+	}
+}
+
+func infix[I comparable, O, Op any](kind operatorKind, op Parser[I, Op], fold func(x O, op Op, y O) (O, error)) Operator[I, O] {
+	return Operator[I, O]{
+		kind: kind,
+		binary: Convert(op, func(o Op) (func(O, O) (O, error), error) {
+			return func(x, y O) (O, error) {
+				return fold(x, o, y)
+			}, nil
+		}),
+	}
+}
+
+// InfixLeft makes a left associative infix operator like `x - y`.
+// `a - b - c` is parsed as `(a - b) - c`.
+//
+// The `op` parses the operator, and the `fold` makes a value from the left operand, the output of `op`, and the right operand.
+func InfixLeft[I comparable, O, Op any](op Parser[I, Op], fold func(x O, op Op, y O) (O, error)) Operator[I, O] {
+	return infix(infixLeftOperator, op, fold)
+}
+
+// InfixRight makes a right associative infix operator like `x ^ y`.
+// `a ^ b ^ c` is parsed as `a ^ (b ^ c)`.
+//
+// The `op` parses the operator, and the `fold` makes a value from the left operand, the output of `op`, and the right operand.
+func InfixRight[I comparable, O, Op any](op Parser[I, Op], fold func(x O, op Op, y O) (O, error)) Operator[I, O] {
+	return infix(infixRightOperator, op, fold)
+}
+
+// InfixNonAssoc makes a non associative infix operator like `x == y`.
+// `a == b == c` is not allowed, so the parser stops after `a == b`.
+//
+// The `op` parses the operator, and the `fold` makes a value from the left operand, the output of `op`, and the right operand.
+func InfixNonAssoc[I comparable, O, Op any](op Parser[I, Op], fold func(x O, op Op, y O) (O, error)) Operator[I, O] {
+	return infix(infixNonAssocOperator, op, fold)
+}
+
+type expressionParser[I comparable, O any] struct {
+	Atom   Parser[I, O]
+	Levels [][]Operator[I, O]
+}
+
+// Expression makes a parser for expressions that built from `atom` and operators.
+//
+// The `levels` is a list of operators that have the same precedence, in order from the highest precedence to the lowest.
+// For example, the levels for arithmetic expressions are like below.
+//
+//	parcon.Expression(number,
+//		[]parcon.Operator[rune, int]{parcon.Prefix(minus, negate)},
+//		[]parcon.Operator[rune, int]{parcon.InfixLeft(star, mul), parcon.InfixLeft(slash, div)},
+//		[]parcon.Operator[rune, int]{parcon.InfixLeft(plus, add), parcon.InfixLeft(minus, sub)},
+//	)
+//
+// Operators are tried in the order of the `levels`, so please place a longer operator first if operators have the same prefix, like `**` and `*`.
+// If the parser found an operator but failed to parse its operand, it stops before the operator without error, as the same as SeparatedList.
+func Expression[I comparable, O any](atom Parser[I, O], levels ...[]Operator[I, O]) Parser[I, O] {
+	return expressionParser[I, O]{atom, levels}
+}
+
+func (e expressionParser[I, O]) Parse(input []I, verbose bool) (output O, remain []I, err error) {
+	if tracing() {
+		defer trace("Expression", e, input).leave(&remain, &err)
+	}
+
+	return e.parse(input, 0, verbose)
+}
+
+// parse parses an expression that consists of operators that have precedence `minPrec` or higher.
+// The precedence of e.Levels[i] is len(e.Levels) - i.
+func (e expressionParser[I, O]) parse(input []I, minPrec int, verbose bool) (output O, remain []I, err error) {
+	output, remain, err = e.parsePrefix(input, verbose)
+	if err != nil {
+		return
+	}
+
+	s := sessionOf(input)
+	nonAssoc := -1
+
+	for {
+		found := false
+
+		for i, level := range e.Levels {
+			prec := len(e.Levels) - i
+			if prec < minPrec {
+				break
+			}
+
+			for _, op := range level {
+				var o O
+				var r []I
+
+				if err = s.step(); err != nil {
+					return
+				}
+				cp := s.checkpoint()
+
+				switch op.kind {
+				case postfixOperator:
+					var fn func(O) (O, error)
+					fn, r, err = op.unary.Parse(remain, false)
+					if isFatal(err) {
+						return
+					} else if err != nil {
+						s.rollback(cp)
+						continue
+					}
+					o, err = fn(output)
+				case infixLeftOperator, infixRightOperator, infixNonAssocOperator:
+					if op.kind == infixNonAssocOperator && prec == nonAssoc {
+						continue
+					}
+
+					var fn func(O, O) (O, error)
+					fn, r, err = op.binary.Parse(remain, false)
+					if isFatal(err) {
+						return
+					} else if err != nil {
+						s.rollback(cp)
+						continue
+					}
+
+					next := prec + 1
+					if op.kind == infixRightOperator {
+						next = prec
+					}
+
+					var rhs O
+					rhs, r, err = e.parse(r, next, false)
+					if isFatal(err) {
+						return
+					} else if err != nil {
+						s.rollback(cp)
+						continue
+					}
+					o, err = fn(output, rhs)
+
+					if op.kind == infixNonAssocOperator {
+						nonAssoc = prec
+					}
+				default:
+					continue
+				}
+				if err != nil {
+					return
+				}
+
+				output, remain, found = o, r, true
+				break
+			}
+			if found {
+				break
+			}
+		}
+
+		if !found {
+			return output, remain, nil
+		}
+	}
+}
+
+// parsePrefix parses an atom that may have prefix operators.
+func (e expressionParser[I, O]) parsePrefix(input []I, verbose bool) (output O, remain []I, err error) {
+	s := sessionOf(input)
+
+	for i, level := range e.Levels {
+		prec := len(e.Levels) - i
+
+		for _, op := range level {
+			if op.kind != prefixOperator {
+				continue
+			}
+
+			if err = s.step(); err != nil {
+				return output, input, err
+			}
+			cp := s.checkpoint()
+
+			fn, r, err := op.unary.Parse(input, false)
+			if isFatal(err) {
+				return output, input, err
+			} else if err != nil {
+				s.rollback(cp)
+				continue
+			}
+
+			x, r, err := e.parse(r, prec, false)
+			if isFatal(err) {
+				return output, input, err
+			} else if err != nil {
+				s.rollback(cp)
+				continue
+			}
+
+			output, err = fn(x)
+			return output, r, err
+		}
+	}
+
+	return e.Atom.Parse(input, verbose)
+}
+
+func (e expressionParser[I, O]) String() string {
+	return fmt.Sprintf("expression of [%v]", e.Atom)
+}
+
+func (e expressionParser[I, O]) Kind() Kind {
+	return KindExpression
+}
+
+func (e expressionParser[I, O]) Children() []Node {
+	ns := nodesOf(e.Atom)
+	for _, level := range e.Levels {
+		for _, op := range level {
+			if op.unary != nil {
+				ns = append(ns, NodeOf(op.unary))
+			} else {
+				ns = append(ns, NodeOf(op.binary))
+			}
+		}
+	}
+	return ns
+}
+
+// operators returns the atom and operators for grammar writers.
+func (e expressionParser[I, O]) operators() (atom Node, prefix, postfix, infix []Node) {
+	for _, level := range e.Levels {
+		for _, op := range level {
+			switch op.kind {
+			case prefixOperator:
+				prefix = append(prefix, NodeOf(op.unary))
+			case postfixOperator:
+				postfix = append(postfix, NodeOf(op.unary))
+			default:
+				infix = append(infix, NodeOf(op.binary))
+			}
+		}
+	}
+	return NodeOf(e.Atom), prefix, postfix, infix
+}
@@ -0,0 +1,151 @@
+package parcon_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/macrat/parcon"
+)
+
+func ExampleExpression() {
+	symbol := func(s string) parcon.Parser[rune, string] {
+		return parcon.WithEnclosure(parcon.Optional(parcon.MultiSpaces), parcon.TagStr(s, s), parcon.Optional(parcon.MultiSpaces))
+	}
+
+	var expr parcon.Ref[rune, float64]
+
+	atom := parcon.Or(
+		parcon.Convert(parcon.MultiDigits, parcon.ToFloat),
+		parcon.WithEnclosure(symbol("("), parcon.Parser[rune, float64](&expr), symbol(")")),
+	)
+
+	binary := func(x float64, op string, y float64) (float64, error) {
+		switch op {
+		case "^":
+			return math.Pow(x, y), nil
+		case "*":
+			return x * y, nil
+		case "/":
+			return x / y, nil
+		case "+":
+			return x + y, nil
+		default:
+			return x - y, nil
+		}
+	}
+
+	expr.Set(parcon.Named("EXPRESSION", parcon.Expression(
+		atom,
+		[]parcon.Operator[rune, float64]{
+			parcon.Postfix(symbol("%"), func(x float64, _ string) (float64, error) {
+				return x / 100, nil
+			}),
+		},
+		[]parcon.Operator[rune, float64]{
+			parcon.Prefix(symbol("-"), func(_ string, x float64) (float64, error) {
+				return -x, nil
+			}),
+		},
+		[]parcon.Operator[rune, float64]{
+			parcon.InfixRight(symbol("^"), binary),
+		},
+		[]parcon.Operator[rune, float64]{
+			parcon.InfixLeft(symbol("*"), binary),
+			parcon.InfixLeft(symbol("/"), binary),
+		},
+		[]parcon.Operator[rune, float64]{
+			parcon.InfixLeft(symbol("+"), binary),
+			parcon.InfixLeft(symbol("-"), binary),
+		},
+	)))
+
+	for _, input := range []string{"1 + 2 * 3", "(1 + 2) * 3", "10 - 4 - 3", "2 ^ 3 ^ 2", "-2 ^ 2", "50% * 4", "1 + "} {
+		output, remain, err := expr.Parse([]rune(input), true)
+		fmt.Printf("%s => output:%v remain:%#v err:%v\n", input, output, string(remain), err)
+	}
+
+	// OUTPUT:
+	// 1 + 2 * 3 => output:7 remain:"" err:<nil>
+	// (1 + 2) * 3 => output:9 remain:"" err:<nil>
+	// 10 - 4 - 3 => output:3 remain:"" err:<nil>
+	// 2 ^ 3 ^ 2 => output:512 remain:"" err:<nil>
+	// -2 ^ 2 => output:4 remain:"" err:<nil>
+	// 50% * 4 => output:2 remain:"" err:<nil>
+	// 1 +  => output:1 remain:" + " err:<nil>
+}
+
+func ExampleInfixNonAssoc() {
+	number := parcon.Convert(parcon.MultiDigits, parcon.ToInt)
+
+	parser := parcon.Expression(
+		parcon.Convert(number, func(x int) (any, error) {
+			return x, nil
+		}),
+		[]parcon.Operator[rune, any]{
+			parcon.InfixNonAssoc(parcon.TagStr("EQUAL", "=="), func(x any, _ string, y any) (any, error) {
+				return x == y, nil
+			}),
+		},
+	)
+
+	output, remain, err := parser.Parse([]rune("1==1==1"), true)
+	fmt.Printf("output:%v remain:%#v err:%v\n", output, string(remain), err)
+
+	// OUTPUT:
+	// output:true remain:"==1" err:<nil>
+}
+
+func ExampleExpression_grammar() {
+	number := parcon.Convert(parcon.MultiDigits, parcon.ToInt)
+	apply := func(x int, _ string, y int) (int, error) {
+		return x, nil
+	}
+	unary := func(_ string, x int) (int, error) {
+		return x, nil
+	}
+
+	parser := parcon.Named("EXPR", parcon.Expression(
+		number,
+		[]parcon.Operator[rune, int]{
+			parcon.Postfix(parcon.TagStr("BANG", "!"), func(x int, _ string) (int, error) {
+				return x, nil
+			}),
+		},
+		[]parcon.Operator[rune, int]{parcon.Prefix(parcon.TagStr("MINUS", "-"), unary)},
+		[]parcon.Operator[rune, int]{parcon.InfixLeft(parcon.TagStr("STAR", "*"), apply)},
+		[]parcon.Operator[rune, int]{parcon.InfixLeft(parcon.TagStr("PLUS", "+"), apply), parcon.InfixLeft(parcon.TagStr("MINUS", "-"), apply)},
+	))
+
+	fmt.Print(parcon.EBNF(parser))
+	fmt.Print(parcon.PEG(parser))
+
+	// OUTPUT:
+	// EXPR = { "-" } , ? DIGIT ? , { ? DIGIT ? } , { "!" } , { ( "*" | "+" | "-" ) , { "-" } , ? DIGIT ? , { ? DIGIT ? } , { "!" } } ;
+	// EXPR <- "-"* DIGIT+ "!"* (("*" / "+" / "-") "-"* DIGIT+ "!"*)*
+	//
+	// # DIGIT is defined in Go code.
+}
+
+func Test_expressionSteps(t *testing.T) {
+	x := parcon.TagStr("X", "x")
+	parser := parcon.Expression(
+		x,
+		[]parcon.Operator[rune, string]{parcon.Prefix(parcon.TagStr("MINUS", "-"), func(_ string, x string) (string, error) {
+			return x, nil
+		})},
+		[]parcon.Operator[rune, string]{parcon.InfixLeft(parcon.TagStr("PLUS", "+"), func(x string, _ string, y string) (string, error) {
+			return x, nil
+		})},
+	)
+
+	for _, input := range []string{"x+x+x+x+x+x+x+x", "--------x"} {
+		if _, err := parcon.ParseString(parser, input, parcon.MaxSteps(4)); !errors.Is(err, parcon.ErrStepsExceeded) {
+			t.Errorf("%q: expected ErrStepsExceeded but got %v", input, err)
+		}
+		if _, err := parcon.ParseString(parser, input, parcon.MaxSteps(100)); err != nil {
+			t.Errorf("%q: unexpected error: %v", input, err)
+		}
+	}
+}
@@ -0,0 +1,499 @@
+package parcon
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// GenerateGo generates Go source code of a parser for the PEG `grammar`, in the package `pkg`.
+// The grammar is the same as CompilePEG.
+//
+// The generated code does not depend on parcon, and defines a function and types like below, where Name is `name`.
+//
+//	func ParseName(input string, actions map[string]func(any) (any, error)) (any, error)
+//
+//	type NameParser struct { ... }
+//	func (p *NameParser) Parse(input string) ([]NameNode, error)
+//
+//	type NameNode struct {
+//		Rule       string
+//		Start, End int
+//		Size       int
+//	}
+//
+// Both parse the whole of `input` using recursive descent functions instead of combinators, so they are faster than CompilePEG.
+//
+// ParseName returns the same output as the parser made by CompilePEG, and calls the actions.
+// It allocates memory for the outputs, because values are boxed in `any`, and sequences and repetitions make []any.
+// An error from an action is returned as is, instead of trying other alternatives.
+//
+// NameParser.Parse does not make outputs nor call actions, but returns the syntax tree of rules without allocation.
+// The tree is a list of nodes in pre-order, and each node is the span of a rule in the input.
+// The Size of a node is the number of nodes in its subtree including itself, so the children follow the node, and the next sibling is at the index plus Size.
+// The nodes are stored in the buffer of NameParser, that is reused in the next call of Parse.
+// So Parse does not allocate memory once the buffer grown enough, except for errors.
+//
+// The errors are different from CompilePEG.
+// The error is reported at the furthest position that the parser reached, with all things expected at there.
+//
+// Parsers built in Go can not be generated because they have Go functions like Convert, so please write the grammar in PEG and use actions.
+// Please see the command parcon-gen to use it with go:generate.
+func GenerateGo(grammar, pkg, name string) ([]byte, error) {
+	if !token.IsIdentifier(pkg) {
+		return nil, fmt.Errorf("parcon: invalid package name: %q", pkg)
+	}
+	if !token.IsIdentifier(name) {
+		return nil, fmt.Errorf("parcon: invalid parser name: %q", name)
+	}
+
+	defs, err := parsePEG(grammar)
+	if err != nil {
+		return nil, err
+	}
+
+	r, size := utf8.DecodeRuneInString(name)
+	exported := string(unicode.ToUpper(r)) + name[size:]
+	g := &goGenerator{
+		typ:        exported + "Parser",
+		node:       exported + "Node",
+		rules:      make(map[string]pegExpr),
+		matchRules: make(map[string]bool),
+		matchFuncs: make(map[string]string),
+		actions:    make(map[string]int),
+	}
+	for _, d := range defs {
+		g.rules[d.Name] = d.Expr
+	}
+	for _, d := range defs {
+		if err := g.function("rule_"+d.Name, d.Expr, false); err != nil {
+			return nil, err
+		}
+	}
+	for _, d := range defs {
+		// All rules have to be matchable for the syntax tree.
+		if _, err := g.call(pegRuleRef(d.Name), true); err != nil {
+			return nil, err
+		}
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, goHeader, pkg)
+	names := make([]string, len(g.actionNames))
+	for i, n := range g.actionNames {
+		names[i] = strconv.Quote(n)
+	}
+	fmt.Fprintf(&b, goEntry, name, g.typ, defs[0].Name, len(names), strings.Join(names, ", "), g.node)
+	b.Write(g.buf.Bytes())
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("parcon: failed to generate Go code: %w", err)
+	}
+	return src, nil
+}
+
+const goHeader = `// Code generated by parcon-gen. DO NOT EDIT.
+
+package %s
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+`
+
+const goEntry = `
+// Parse%[1]s parses the whole of input, and returns the output of the rule %[3]s.
+// The actions are called by the names in the grammar.
+func Parse%[1]s(input string, actions map[string]func(any) (any, error)) (any, error) {
+	p := %[2]s{input: input}
+	for i, name := range [%[4]d]string{%[5]s} {
+		fn, ok := actions[name]
+		if !ok {
+			return nil, fmt.Errorf("undefined action %%q", name)
+		}
+		p.actions[i] = fn
+	}
+
+	output, pos, ok := p.rule_%[3]s(0)
+	if p.err != nil {
+		return nil, p.err
+	}
+	if ok && pos == len(input) {
+		return output, nil
+	}
+	if ok {
+		p.fail(pos, "EOF")
+	}
+	return nil, p.error()
+}
+
+// %[2]s parses input into the syntax tree of rules, without allocation once its buffers grown enough.
+// The zero value is ready to use, and it can be reused to parse other inputs, but not from multiple goroutines at the same time.
+type %[2]s struct {
+	input   string
+	actions [%[4]d]func(any) (any, error)
+	err     error
+
+	// silent is greater than 0 while parsing in a negative lookahead, that does not report errors.
+	silent int
+
+	// failPos is the furthest position that failed, and expected is things expected at there.
+	failPos  int
+	expected []string
+
+	// nodes is the syntax tree that made while matching.
+	nodes []%[6]s
+}
+
+// %[6]s is a node of the syntax tree that made by %[2]s.
+// It is the span of a rule in the input.
+type %[6]s struct {
+	Rule       string
+	Start, End int
+
+	// Size is the number of nodes in the subtree including this node.
+	// The children follow this node, and the next sibling is at the index plus Size.
+	Size int
+}
+
+// Parse parses the whole of input, and returns the syntax tree of rules in pre-order.
+// It does not call actions.
+// The nodes are valid until the next call of Parse.
+func (p *%[2]s) Parse(input string) ([]%[6]s, error) {
+	p.input, p.err, p.silent, p.failPos = input, nil, 0, 0
+	p.expected, p.nodes = p.expected[:0], p.nodes[:0]
+
+	_, pos, ok := p.match_%[3]s(0)
+	if ok && pos == len(input) {
+		return p.nodes, nil
+	}
+	if ok {
+		p.fail(pos, "EOF")
+	}
+	return nil, p.error()
+}
+
+// fail records that expected is not found at pos.
+func (p *%[2]s) fail(pos int, expected string) {
+	if p.silent > 0 || pos < p.failPos {
+		return
+	}
+	if pos > p.failPos {
+		p.failPos = pos
+		p.expected = p.expected[:0]
+	}
+	for _, e := range p.expected {
+		if e == expected {
+			return
+		}
+	}
+	p.expected = append(p.expected, expected)
+}
+
+// error makes an error at the furthest position that failed.
+func (p *%[2]s) error() error {
+	line, column := 1, 1
+	for _, c := range p.input[:p.failPos] {
+		if c == '\n' {
+			line++
+			column = 1
+		} else {
+			column++
+		}
+	}
+
+	got := "end of input"
+	if p.failPos < len(p.input) {
+		c, _ := utf8.DecodeRuneInString(p.input[p.failPos:])
+		got = fmt.Sprintf("%%q", string(c))
+	}
+
+	var expected string
+	switch n := len(p.expected); n {
+	case 0:
+		expected = "nothing"
+	case 1:
+		expected = p.expected[0]
+	case 2:
+		expected = p.expected[0] + " or " + p.expected[1]
+	default:
+		expected = strings.Join(p.expected[:n-1], ", ") + ", or " + p.expected[n-1]
+	}
+
+	return fmt.Errorf("invalid input at %%d:%%d: expected %%s but got %%s", line, column, expected, got)
+}
+`
+
+// goGenerator generates Go functions for PEG expressions.
+//
+// Each expression has two kinds of functions.
+// One returns the output, and the other one only matches without making the output, for captures and lookaheads.
+type goGenerator struct {
+	typ         string
+	node        string
+	buf         bytes.Buffer
+	funcs       int
+	rules       map[string]pegExpr
+	matchRules  map[string]bool
+	matchFuncs  map[string]string
+	actions     map[string]int
+	actionNames []string
+}
+
+// function generates a function named `name` that parses `e`.
+func (g *goGenerator) function(name string, e pegExpr, match bool) error {
+	body, err := e.generate(g, match)
+	if err != nil {
+		return err
+	}
+
+	verb := "parses"
+	if match {
+		verb = "matches"
+	}
+	fmt.Fprintf(&g.buf, "\n// %s %s %s\n", name, verb, e)
+	fmt.Fprintf(&g.buf, "func (p *%s) %s(pos int) (any, int, bool) {\n%s}\n", g.typ, name, body)
+	return nil
+}
+
+// call returns an expression that calls a function to parse `e`.
+// The function returns nil as the output if `match` is true.
+func (g *goGenerator) call(e pegExpr, match bool) (string, error) {
+	if r, ok := e.(pegRuleRef); ok {
+		rule, ok := g.rules[string(r)]
+		if !ok {
+			return "", fmt.Errorf("parcon: undefined rule %q in PEG grammar", string(r))
+		}
+		if !match {
+			return "p.rule_" + string(r) + "(pos)", nil
+		}
+
+		if !g.matchRules[string(r)] {
+			g.matchRules[string(r)] = true
+			if err := g.matchRule(string(r), rule); err != nil {
+				return "", err
+			}
+		}
+		return "p.match_" + string(r) + "(pos)", nil
+	}
+
+	// The same expressions can share the function to match, because it does not depend on actions.
+	if name, ok := g.matchFuncs[e.String()]; match && ok {
+		return "p." + name + "(pos)", nil
+	}
+
+	g.funcs++
+	name := fmt.Sprintf("expr%d", g.funcs)
+	if match {
+		name = fmt.Sprintf("match%d", g.funcs)
+		g.matchFuncs[e.String()] = name
+	}
+	if err := g.function(name, e, match); err != nil {
+		return "", err
+	}
+	return "p." + name + "(pos)", nil
+}
+
+// matchRule generates a function that matches the rule `name`, and records it as a node of the syntax tree.
+func (g *goGenerator) matchRule(name string, e pegExpr) error {
+	call, err := g.call(e, true)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(&g.buf, "\n// match_%s matches the rule %s, and records it as a node.\n", name, name)
+	fmt.Fprintf(&g.buf, "func (p *%s) match_%s(pos int) (any, int, bool) {\n", g.typ, name)
+	fmt.Fprintf(&g.buf, "i := len(p.nodes)\np.nodes = append(p.nodes, %s{Rule: %s, Start: pos})\n", g.node, strconv.Quote(name))
+	fmt.Fprintf(&g.buf, "_, end, ok := %s\n", call)
+	g.buf.WriteString("if !ok {\np.nodes = p.nodes[:i]\nreturn nil, pos, false\n}\n")
+	g.buf.WriteString("p.nodes[i].End, p.nodes[i].Size = end, len(p.nodes)-i\nreturn nil, end, true\n}\n")
+	return nil
+}
+
+// action returns the index of the action named `name`.
+func (g *goGenerator) action(name string) int {
+	if i, ok := g.actions[name]; ok {
+		return i
+	}
+	g.actions[name] = len(g.actionNames)
+	g.actionNames = append(g.actionNames, name)
+	return g.actions[name]
+}
+
+// goOutput returns `output`, or "nil" if `match` is true.
+func goOutput(output string, match bool) string {
+	if match {
+		return "nil"
+	}
+	return output
+}
+
+func (e pegChoice) generate(g *goGenerator, match bool) (string, error) {
+	var b strings.Builder
+	for _, x := range e {
+		call, err := g.call(x, match)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&b, "if output, end, ok := %s; ok {\nreturn output, end, true\n}\n", call)
+		if !match {
+			// Stop at the error from an action, instead of trying other alternatives.
+			b.WriteString("if p.err != nil {\nreturn nil, pos, false\n}\n")
+		}
+	}
+	b.WriteString("return nil, pos, false\n")
+	return b.String(), nil
+}
+
+func (e pegSequence) generate(g *goGenerator, match bool) (string, error) {
+	var b strings.Builder
+
+	if len(e.Items) > 0 {
+		b.WriteString("start := pos\n")
+	}
+	if match && len(e.Items) > 0 {
+		// Remove nodes of the items that matched, if a following item failed.
+		b.WriteString("n := len(p.nodes)\nvar ok bool\n")
+	}
+	vs := make([]string, len(e.Items))
+	for i, x := range e.Items {
+		call, err := g.call(x, match)
+		if err != nil {
+			return "", err
+		}
+		if match {
+			fmt.Fprintf(&b, "_, pos, ok = %s\n", call)
+		} else {
+			vs[i] = fmt.Sprintf("v%d", i)
+			fmt.Fprintf(&b, "%s, pos, ok := %s\n", vs[i], call)
+		}
+		if match {
+			b.WriteString("if !ok {\np.nodes = p.nodes[:n]\nreturn nil, start, false\n}\n")
+		} else {
+			b.WriteString("if !ok {\nreturn nil, start, false\n}\n")
+		}
+	}
+
+	var output string
+	switch len(vs) {
+	case 0:
+		output = "nil"
+	case 1:
+		output = vs[0]
+	default:
+		output = "[]any{" + strings.Join(vs, ", ") + "}"
+	}
+
+	// Actions are not called while matching, because the outputs are not used.
+	if match || e.Action == "" {
+		fmt.Fprintf(&b, "return %s, pos, true\n", goOutput(output, match))
+	} else {
+		fmt.Fprintf(&b, "output, err := p.actions[%d](%s)\n", g.action(e.Action), output)
+		b.WriteString("if err != nil {\nif p.err == nil {\np.err = err\n}\nreturn nil, pos, false\n}\n")
+		b.WriteString("return output, pos, true\n")
+	}
+	return b.String(), nil
+}
+
+func (e pegPrefix) generate(g *goGenerator, match bool) (string, error) {
+	call, err := g.call(e.Expr, true)
+	if err != nil {
+		return "", err
+	}
+
+	// Lookaheads do not consume input, so nodes that matched in them are removed.
+	switch e.Op {
+	case '&':
+		return fmt.Sprintf("n := len(p.nodes)\n_, _, ok := %s\np.nodes = p.nodes[:n]\nreturn nil, pos, ok\n", call), nil
+	case '!':
+		expected := strconv.Quote(fmt.Sprintf("not [%v]", e.Expr))
+		return fmt.Sprintf("n := len(p.nodes)\np.silent++\n_, _, ok := %s\np.silent--\np.nodes = p.nodes[:n]\nif ok {\np.fail(pos, %s)\nreturn nil, pos, false\n}\nreturn nil, pos, true\n", call, expected), nil
+	default: // '$'
+		return fmt.Sprintf("if _, end, ok := %s; ok {\nreturn %s, end, true\n}\nreturn nil, pos, false\n", call, goOutput("p.input[pos:end]", match)), nil
+	}
+}
+
+func (e pegSuffix) generate(g *goGenerator, match bool) (string, error) {
+	call, err := g.call(e.Expr, match)
+	if err != nil {
+		return "", err
+	}
+
+	if e.Op == '?' {
+		if match {
+			return fmt.Sprintf("if _, end, ok := %s; ok {\nreturn nil, end, true\n}\nreturn nil, pos, true\n", call), nil
+		}
+		return fmt.Sprintf("if output, end, ok := %s; ok {\nreturn output, end, true\n}\nif p.err != nil {\nreturn nil, pos, false\n}\nreturn nil, pos, true\n", call), nil
+	}
+
+	// '*' or '+'
+	var b strings.Builder
+	if match {
+		b.WriteString("count := 0\nfor {\n")
+		fmt.Fprintf(&b, "n := len(p.nodes)\n_, end, ok := %s\n", call)
+		b.WriteString("if !ok || (count > 0 && end == pos) {\np.nodes = p.nodes[:n]\nbreak\n}\n")
+		b.WriteString("count++\npos = end\n}\n")
+		if e.Op == '+' {
+			b.WriteString("if count == 0 {\nreturn nil, pos, false\n}\n")
+		}
+		b.WriteString("return nil, pos, true\n")
+	} else {
+		b.WriteString("outputs := []any{}\nfor {\n")
+		fmt.Fprintf(&b, "output, end, ok := %s\n", call)
+		b.WriteString("if !ok || (len(outputs) > 0 && end == pos) {\nbreak\n}\n")
+		b.WriteString("outputs = append(outputs, output)\npos = end\n}\n")
+		b.WriteString("if p.err != nil {\nreturn nil, pos, false\n}\n")
+		if e.Op == '+' {
+			b.WriteString("if len(outputs) == 0 {\nreturn nil, pos, false\n}\n")
+		}
+		b.WriteString("return outputs, pos, true\n")
+	}
+	return b.String(), nil
+}
+
+func (e pegRuleRef) generate(g *goGenerator, match bool) (string, error) {
+	call, err := g.call(e, match)
+	if err != nil {
+		return "", err
+	}
+	return "return " + call + "\n", nil
+}
+
+func (e pegLiteral) generate(g *goGenerator, match bool) (string, error) {
+	s := strconv.Quote(string(e))
+	return fmt.Sprintf("if strings.HasPrefix(p.input[pos:], %s) {\nreturn %s, pos + %d, true\n}\np.fail(pos, %s)\nreturn nil, pos, false\n", s, goOutput(s, match), len(string(e)), strconv.Quote(e.String())), nil
+}
+
+func (e pegClass) generate(g *goGenerator, match bool) (string, error) {
+	conds := make([]string, len(e.Ranges))
+	for i, r := range e.Ranges {
+		if r[0] == r[1] {
+			conds[i] = "c == " + strconv.QuoteRune(r[0])
+		} else {
+			conds[i] = strconv.QuoteRune(r[0]) + " <= c && c <= " + strconv.QuoteRune(r[1])
+		}
+	}
+	c, cond := "c", strings.Join(conds, " || ")
+	if len(conds) == 0 {
+		c, cond = "_", "false"
+	}
+	if e.Negate {
+		cond = "!(" + cond + ")"
+	} else if len(conds) > 1 {
+		cond = "(" + cond + ")"
+	}
+
+	return fmt.Sprintf("if %s, n := utf8.DecodeRuneInString(p.input[pos:]); n > 0 && %s {\nreturn %s, pos + n, true\n}\np.fail(pos, %s)\nreturn nil, pos, false\n", c, cond, goOutput("p.input[pos : pos+n]", match), strconv.Quote(e.String())), nil
+}
+
+func (e pegAny) generate(g *goGenerator, match bool) (string, error) {
+	return fmt.Sprintf("if _, n := utf8.DecodeRuneInString(p.input[pos:]); n > 0 {\nreturn %s, pos + n, true\n}\np.fail(pos, \"ANYTHING\")\nreturn nil, pos, false\n", goOutput("p.input[pos : pos+n]", match)), nil
+}
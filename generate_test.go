@@ -0,0 +1,45 @@
+package parcon_test
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/macrat/parcon"
+)
+
+func ExampleGenerateGo() {
+	src, err := parcon.GenerateGo(`
+		greeting <- word (", " word)*
+		word     <- $[a-z]+
+	`, "example", "Greeting")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	for _, line := range strings.Split(string(src), "\n") {
+		if strings.HasPrefix(line, "func ") {
+			fmt.Println(line)
+		}
+	}
+
+	// OUTPUT:
+	// func ParseGreeting(input string, actions map[string]func(any) (any, error)) (any, error) {
+	// func (p *GreetingParser) Parse(input string) ([]GreetingNode, error) {
+	// func (p *GreetingParser) fail(pos int, expected string) {
+	// func (p *GreetingParser) error() error {
+	// func (p *GreetingParser) expr3(pos int) (any, int, bool) {
+	// func (p *GreetingParser) expr2(pos int) (any, int, bool) {
+	// func (p *GreetingParser) expr1(pos int) (any, int, bool) {
+	// func (p *GreetingParser) rule_greeting(pos int) (any, int, bool) {
+	// func (p *GreetingParser) match5(pos int) (any, int, bool) {
+	// func (p *GreetingParser) match4(pos int) (any, int, bool) {
+	// func (p *GreetingParser) rule_word(pos int) (any, int, bool) {
+	// func (p *GreetingParser) match7(pos int) (any, int, bool) {
+	// func (p *GreetingParser) match_word(pos int) (any, int, bool) {
+	// func (p *GreetingParser) match10(pos int) (any, int, bool) {
+	// func (p *GreetingParser) match9(pos int) (any, int, bool) {
+	// func (p *GreetingParser) match8(pos int) (any, int, bool) {
+	// func (p *GreetingParser) match6(pos int) (any, int, bool) {
+	// func (p *GreetingParser) match_greeting(pos int) (any, int, bool) {
+}
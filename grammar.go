@@ -0,0 +1,542 @@
+package parcon
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// EBNF returns the grammar of `parser` in EBNF notation like ISO/IEC 14977.
+//
+// Each Named parser becomes a rule, and the first rule is the `parser` itself.
+// If the `parser` is not Named, the first rule is named "ROOT".
+//
+// Some parsers can not be written in EBNF, like TakeSingle that uses a Go function, or lookahead like Not.
+// They are written as special sequences like `? DIGIT ?`.
+// The precedence of operators in Expression is not written.
+func EBNF(parser any) string {
+	g := newGrammarWriter(false)
+	return g.write(NodeOf(parser))
+}
+
+// PEG returns the grammar of `parser` in PEG notation.
+//
+// Each Named parser becomes a rule, and the first rule is the `parser` itself.
+// If the `parser` is not Named, the first rule is named "ROOT".
+//
+// Parsers that use a Go function like TakeSingle are written as references to rules that not defined in the grammar.
+// The names of them are listed in comments at the end.
+// The precedence of operators in Expression is not written.
+func PEG(parser any) string {
+	g := newGrammarWriter(true)
+	return g.write(NodeOf(parser))
+}
+
+// Precedences of terms in grammar.
+const (
+	precAlt = iota
+	precSeq
+	precPrefix
+	precPostfix
+	precAtom
+)
+
+// term is a part of grammar.
+type term struct {
+	text  string
+	prec  int
+	empty bool
+
+	// repeated is the term that repeated one or more times, if this term is so.
+	repeated *term
+}
+
+type grammarWriter struct {
+	peg   bool
+	rules *ruleSet[term]
+
+	// externals is the names of parsers that not defined in the grammar.
+	externals []string
+}
+
+func newGrammarWriter(peg bool) *grammarWriter {
+	return &grammarWriter{
+		peg:   peg,
+		rules: newRuleSet[term](),
+	}
+}
+
+func (g *grammarWriter) write(root Node) string {
+	g.rules.start(root)
+
+	var b strings.Builder
+	g.rules.each(g.term, func(name string, body term) {
+		if g.peg {
+			fmt.Fprintf(&b, "%s <- %s\n", name, body.text)
+		} else {
+			fmt.Fprintf(&b, "%s = %s ;\n", name, body.text)
+		}
+	})
+
+	if len(g.externals) > 0 {
+		b.WriteString("\n")
+		for _, name := range g.externals {
+			fmt.Fprintf(&b, "# %s is defined in Go code.\n", name)
+		}
+	}
+
+	return b.String()
+}
+
+// external returns a reference to a parser that not defined in the grammar.
+func (g *grammarWriter) external(name string) term {
+	if !g.peg {
+		return term{text: "? " + name + " ?", prec: precAtom}
+	}
+	name = ruleName(name)
+	for _, n := range g.externals {
+		if n == name {
+			return term{text: name, prec: precAtom}
+		}
+	}
+	g.externals = append(g.externals, name)
+	return term{text: name, prec: precAtom}
+}
+
+func (g *grammarWriter) term(node Node) term {
+	return g.rules.convert(node, g.nodeTerm, func(name string) term {
+		return term{text: name, prec: precAtom}
+	})
+}
+
+func (g *grammarWriter) nodeTerm(node Node) term {
+	children := node.Children()
+
+	switch node.Kind() {
+	case KindNamed:
+		return term{text: g.rules.named(node), prec: precAtom}
+	case KindTag:
+		if s, ok := literalString(node.(LiteralNode).Literal()); ok {
+			return g.literal(s)
+		}
+		return g.external(node.String())
+	case KindOneOf, KindOneOfList, KindNoneOf, KindNoneOfList:
+		list, ok := literalString(node.(LiteralNode).Literal())
+		if !ok {
+			return g.external(node.String())
+		}
+		var t term
+		if node.Kind() == KindOneOf || node.Kind() == KindOneOfList {
+			t = g.set(list)
+		} else {
+			t = g.exceptSet(list)
+		}
+		if node.Kind() == KindOneOfList || node.Kind() == KindNoneOfList {
+			t = g.repeat(t, 1, -1)
+		}
+		return t
+	case KindAnything:
+		if g.peg {
+			return term{text: ".", prec: precAtom}
+		}
+		return term{text: "? any character ?", prec: precAtom}
+	case KindNothing:
+		return term{empty: true}
+	case KindEOF:
+		if g.peg {
+			return term{text: "!.", prec: precPrefix}
+		}
+		return term{text: "? end of input ?", prec: precAtom}
+	case KindTakeSingle:
+		return g.external(node.String())
+	case KindToken:
+		if t, ok := node.(tokenParser); ok && t.MatchText {
+			return g.literal(t.Text)
+		}
+		return g.external(node.String())
+	case KindTakeWhile:
+		return g.repeat(g.external(node.String()), 1, -1)
+	case KindOptional:
+		return g.repeat(g.term(children[0]), 0, 1)
+	case KindOr:
+		ts := make([]term, len(children))
+		for i, c := range children {
+			ts[i] = g.term(c)
+		}
+		return g.alt(ts...)
+	case KindSequence, KindPair, KindWithEnclosure, KindMatchOnly:
+		ts := make([]term, len(children))
+		for i, c := range children {
+			ts[i] = g.term(c)
+		}
+		return g.seq(ts...)
+	case KindMany:
+		min, max := node.(RepeatNode).Bounds()
+		return g.repeat(g.term(children[0]), int(min), bound(max))
+	case KindAligned:
+		return g.repeat(g.term(children[0]), 1, -1)
+	case KindIndentBlock:
+		return g.seq(g.term(children[0]), g.repeat(g.term(children[1]), 1, -1))
+	case KindSeparatedList:
+		min, max := node.(RepeatNode).Bounds()
+		x := g.term(children[0])
+		rest := g.seq(g.term(children[1]), x)
+		if min == 0 {
+			return g.repeat(g.seq(x, g.repeat(rest, 0, bound(max)-1)), 0, 1)
+		}
+		return g.seq(x, g.repeat(rest, int(min)-1, bound(max)-1))
+	case KindPeek, KindNot:
+		t := g.term(children[0])
+		switch {
+		case g.peg && node.Kind() == KindPeek:
+			return term{text: "&" + g.paren(t, precPostfix), prec: precPrefix}
+		case g.peg:
+			return term{text: "!" + g.paren(t, precPostfix), prec: precPrefix}
+		case node.Kind() == KindPeek:
+			return term{text: "? followed by " + t.text + " ?", prec: precAtom}
+		default:
+			return term{text: "? not " + t.text + " ?", prec: precAtom}
+		}
+	case KindExpression:
+		atom, prefix, postfix, infix := node.(expressionNode).operators()
+		operand := g.seq(
+			g.repeat(g.alt(g.terms(prefix)...), 0, -1),
+			g.term(atom),
+			g.repeat(g.alt(g.terms(postfix)...), 0, -1),
+		)
+		return g.seq(operand, g.repeat(g.seq(g.alt(g.terms(infix)...), operand), 0, -1))
+	case KindCustom:
+		return g.external(node.String())
+	default:
+		// Parsers that do not change the grammar, like Convert or Ref.
+		if len(children) == 0 {
+			return g.external(node.String())
+		}
+		return g.term(children[0])
+	}
+}
+
+func (g *grammarWriter) terms(nodes []Node) []term {
+	ts := make([]term, len(nodes))
+	for i, n := range nodes {
+		ts[i] = g.term(n)
+	}
+	return ts
+}
+
+// paren returns the text of `t` with parentheses if the precedence of `t` is lower than `prec`.
+func (g *grammarWriter) paren(t term, prec int) string {
+	switch {
+	case t.empty:
+		return `""`
+	case t.prec >= prec:
+		return t.text
+	case g.peg:
+		return "(" + t.text + ")"
+	default:
+		return "( " + t.text + " )"
+	}
+}
+
+func (g *grammarWriter) literal(s string) term {
+	if s == "" {
+		return term{empty: true}
+	}
+	printable := strings.IndexFunc(s, func(c rune) bool { return !unicode.IsPrint(c) }) < 0
+	if !g.peg && printable && !strings.Contains(s, `"`) {
+		return term{text: `"` + s + `"`, prec: precAtom}
+	}
+	if !g.peg && printable && !strings.Contains(s, `'`) {
+		return term{text: `'` + s + `'`, prec: precAtom}
+	}
+	if g.peg {
+		return term{text: pegQuote(s), prec: precAtom}
+	}
+	return term{text: strconv.Quote(s), prec: precAtom}
+}
+
+func (g *grammarWriter) set(list string) term {
+	if !g.peg || utf8.RuneCountInString(list) == 1 {
+		var ts []term
+		for _, c := range list {
+			ts = append(ts, g.literal(string(c)))
+		}
+		return g.alt(ts...)
+	}
+
+	var b strings.Builder
+	b.WriteString("[")
+	for _, c := range list {
+		b.WriteString(pegEscape(c, pegClassSpecials))
+	}
+	b.WriteString("]")
+	return term{text: b.String(), prec: precAtom}
+}
+
+func (g *grammarWriter) exceptSet(list string) term {
+	if g.peg {
+		return g.seq(term{text: "!" + g.paren(g.set(list), precPostfix), prec: precPrefix}, term{text: ".", prec: precAtom})
+	}
+	return term{text: "? any character except " + g.set(list).text + " ?", prec: precAtom}
+}
+
+func (g *grammarWriter) alt(ts ...term) term {
+	var ss []string
+	for _, t := range ts {
+		ss = append(ss, g.paren(t, precAlt))
+	}
+
+	switch len(ss) {
+	case 0:
+		return term{empty: true}
+	case 1:
+		return ts[0]
+	}
+	if g.peg {
+		return term{text: strings.Join(ss, " / "), prec: precAlt}
+	}
+	return term{text: strings.Join(ss, " | "), prec: precAlt}
+}
+
+func (g *grammarWriter) seq(ts ...term) term {
+	var xs []term
+	for _, t := range ts {
+		if !t.empty {
+			xs = append(xs, t)
+		}
+	}
+
+	switch len(xs) {
+	case 0:
+		return term{empty: true}
+	case 1:
+		return xs[0]
+	}
+
+	ss := make([]string, len(xs))
+	for i, t := range xs {
+		ss[i] = g.paren(t, precSeq)
+	}
+	if g.peg {
+		return term{text: strings.Join(ss, " "), prec: precSeq}
+	}
+	return term{text: strings.Join(ss, " , "), prec: precSeq}
+}
+
+// repeat returns a term that repeats `t` from `min` to `max` times.
+// The `max` is negative if there is no limit.
+func (g *grammarWriter) repeat(t term, min, max int) term {
+	if t.repeated != nil && min <= 1 && max == 1 {
+		// An optional "one or more" is "zero or more".
+		return g.repeat(*t.repeated, min, -1)
+	}
+
+	r := g.repeatTerm(t, min, max)
+	if min == 1 && max < 0 && !r.empty {
+		r.repeated = &t
+	}
+	return r
+}
+
+func (g *grammarWriter) repeatTerm(t term, min, max int) term {
+	if t.empty || max == 0 {
+		return term{empty: true}
+	}
+
+	var ts []term
+
+	switch {
+	case g.peg && min == 1 && max < 0:
+		return term{text: g.paren(t, precAtom) + "+", prec: precPostfix}
+	case g.peg:
+		for i := 0; i < min; i++ {
+			ts = append(ts, t)
+		}
+	case min == 1:
+		ts = append(ts, t)
+	case min > 1:
+		ts = append(ts, term{text: fmt.Sprintf("%d * %s", min, g.paren(t, precAtom)), prec: precSeq})
+	}
+
+	switch {
+	case max < 0 && g.peg:
+		ts = append(ts, term{text: g.paren(t, precAtom) + "*", prec: precPostfix})
+	case max < 0:
+		ts = append(ts, term{text: "{ " + t.text + " }", prec: precAtom})
+	case g.peg:
+		for i := min; i < max; i++ {
+			ts = append(ts, term{text: g.paren(t, precAtom) + "?", prec: precPostfix})
+		}
+	case max-min == 1:
+		ts = append(ts, term{text: "[ " + t.text + " ]", prec: precAtom})
+	case max-min > 1:
+		ts = append(ts, term{text: fmt.Sprintf("%d * [ %s ]", max-min, t.text), prec: precSeq})
+	}
+
+	return g.seq(ts...)
+}
+
+type grammarRule[T any] struct {
+	name string
+	node Node
+
+	// body is the converted body of the rule, if the rule is made for a recursion that not through Named.
+	body *T
+}
+
+// ruleSet is a set of rules in a grammar, that converted to T.
+type ruleSet[T any] struct {
+	// queue is the rules to convert. The rules in the queue are also in `names`.
+	queue []grammarRule[T]
+	names map[string]bool
+
+	// rules is the names of rules for each Named parser.
+	rules map[Node]string
+
+	// recursions is the pointer parsers that being converted, to detect recursion that not through Named.
+	recursions map[Node]*string
+	anonymous  int
+}
+
+func newRuleSet[T any]() *ruleSet[T] {
+	return &ruleSet[T]{
+		names:      make(map[string]bool),
+		rules:      make(map[Node]string),
+		recursions: make(map[Node]*string),
+	}
+}
+
+// start adds the first rule.
+// The first rule is the Named parser of `root` if exists, otherwise it is named "ROOT".
+func (r *ruleSet[T]) start(root Node) {
+	if named := findNamed(root); named != nil {
+		r.named(named)
+	} else {
+		r.add("ROOT", root)
+	}
+}
+
+// add adds a new rule to the queue, and returns the name of the rule.
+func (r *ruleSet[T]) add(name string, node Node) string {
+	name = r.unique(ruleName(name))
+	r.queue = append(r.queue, grammarRule[T]{name: name, node: node})
+	return name
+}
+
+// unique returns `name` if it is not used yet, otherwise returns `name` with a suffix like "_2".
+// The returned name is marked as used.
+func (r *ruleSet[T]) unique(name string) string {
+	candidate := name
+	for i := 2; r.names[candidate]; i++ {
+		candidate = fmt.Sprintf("%s_%d", name, i)
+	}
+	r.names[candidate] = true
+	return candidate
+}
+
+// named adds the rule of a Named parser if it is not added yet, and returns the name of the rule.
+// Different Named parsers that have the same name are different rules.
+func (r *ruleSet[T]) named(node Node) string {
+	name, ok := r.rules[node]
+	if !ok {
+		name = r.add(node.String(), node.Children()[0])
+		r.rules[node] = name
+	}
+	return name
+}
+
+// convert converts `node` using `fn`.
+// If `node` is a recursive pointer parser like Ref, it makes a new rule and returns a reference that made by `ref`.
+func (r *ruleSet[T]) convert(node Node, fn func(Node) T, ref func(name string) T) T {
+	if reflect.ValueOf(node).Kind() != reflect.Pointer {
+		return fn(node)
+	}
+
+	if name, ok := r.recursions[node]; ok {
+		if *name == "" {
+			r.anonymous++
+			*name = r.unique(fmt.Sprintf("RULE%d", r.anonymous))
+		}
+		return ref(*name)
+	}
+
+	var name string
+	r.recursions[node] = &name
+	body := fn(node)
+	delete(r.recursions, node)
+
+	if name != "" {
+		r.queue = append(r.queue, grammarRule[T]{name: name, body: &body})
+		return ref(name)
+	}
+	return body
+}
+
+// each converts all rules using `fn`, and calls `f` for each rule in order.
+// The rules that found while converting are also converted.
+func (r *ruleSet[T]) each(fn func(Node) T, f func(name string, body T)) {
+	for i := 0; i < len(r.queue); i++ {
+		rule := r.queue[i]
+		if rule.body != nil {
+			f(rule.name, *rule.body)
+		} else {
+			f(rule.name, fn(rule.node))
+		}
+	}
+}
+
+// findNamed finds a Named parser through parsers that do not change the grammar, like Ref or Convert.
+func findNamed(node Node) Node {
+	for {
+		switch node.Kind() {
+		case KindNamed:
+			return node
+		case KindRef, KindLazy, KindMemo, KindConvert, KindConvertWithState, KindReplace, KindCommit, KindWithSpan:
+			children := node.Children()
+			if len(children) == 0 {
+				return nil
+			}
+			node = children[0]
+		default:
+			return nil
+		}
+	}
+}
+
+// bound converts the maximum of RepeatNode to int. It returns -1 if there is no limit.
+func bound(max uint) int {
+	if max == 0 {
+		return -1
+	}
+	return int(max)
+}
+
+// literalString converts the result of LiteralNode.Literal to string, if it is a text.
+func literalString(literal any) (string, bool) {
+	switch x := literal.(type) {
+	case []rune:
+		return string(x), true
+	case []byte:
+		return string(x), utf8.Valid(x)
+	default:
+		return "", false
+	}
+}
+
+// ruleName makes `name` usable as a name of rule.
+func ruleName(name string) string {
+	return strings.Map(func(c rune) rune {
+		if c == '_' || unicode.IsLetter(c) || unicode.IsDigit(c) {
+			return c
+		}
+		return '_'
+	}, name)
+}
+
+// expressionNode is a Node of Expression.
+type expressionNode interface {
+	operators() (atom Node, prefix, postfix, infix []Node)
+}
@@ -0,0 +1,89 @@
+package parcon_test
+
+import (
+	"fmt"
+
+	"github.com/macrat/parcon"
+)
+
+// configParser parses a config file like "name = value" lines.
+func configParser() parcon.Parser[rune, [][]string] {
+	spaces := parcon.Optional(parcon.MultiSpaces)
+
+	key := parcon.Named("KEY", parcon.Convert(parcon.MultiAlphaNums, parcon.ToString))
+	value := parcon.Named("VALUE", parcon.Or(
+		parcon.Convert(parcon.MultiDigits, parcon.ToString),
+		parcon.Convert(parcon.WithEnclosure(
+			parcon.TagStr("QUOTE", `"`),
+			parcon.NoneOfList("CHARACTER", []rune(`"`)),
+			parcon.TagStr("QUOTE", `"`),
+		), parcon.ToString),
+	))
+
+	entry := parcon.Named("ENTRY", parcon.Sequence(
+		parcon.WithSuffix(key, spaces),
+		parcon.WithPrefix(parcon.TagStr("EQUAL", "="), parcon.WithPrefix(spaces, value)),
+	))
+
+	return parcon.Named("CONFIG", parcon.SeparatedList(0, parcon.TagStr("NEWLINE", "\n"), entry))
+}
+
+func ExampleEBNF() {
+	fmt.Print(parcon.EBNF(configParser()))
+
+	// OUTPUT:
+	// CONFIG = [ ENTRY , { "\n" , ENTRY } ] ;
+	// ENTRY = KEY , { " " | "\t" } , "=" , { " " | "\t" } , VALUE ;
+	// KEY = ? ALPHA_NUM ? , { ? ALPHA_NUM ? } ;
+	// VALUE = ? DIGIT ? , { ? DIGIT ? } | '"' , ? any character except '"' ? , { ? any character except '"' ? } , '"' ;
+}
+
+func ExamplePEG() {
+	fmt.Print(parcon.PEG(configParser()))
+
+	// OUTPUT:
+	// CONFIG <- (ENTRY ("\n" ENTRY)*)?
+	// ENTRY <- KEY [ \t]* "=" [ \t]* VALUE
+	// KEY <- ALPHA_NUM+
+	// VALUE <- DIGIT+ / "\"" (!"\"" .)+ "\""
+	//
+	// # ALPHA_NUM is defined in Go code.
+	// # DIGIT is defined in Go code.
+}
+
+func ExamplePEG_recursive() {
+	// A recursive grammar without Named makes a rule automatically.
+	var ref parcon.Ref[rune, string]
+	var parens parcon.Parser[rune, string] = &ref
+	ref.Set(parcon.Or(
+		parcon.WithEnclosure(parcon.TagStr("BEGIN", "("), parens, parcon.TagStr("END", ")")),
+		parcon.TagStr("X", "x"),
+	))
+
+	fmt.Print(parcon.PEG(parcon.Sequence(parens, parcon.Replace(parcon.EOF[rune](), ""))))
+
+	// OUTPUT:
+	// ROOT <- RULE1 !.
+	// RULE1 <- "(" RULE1 ")" / "x"
+}
+
+func ExamplePEG_sameNames() {
+	// Different parsers are different rules, even if they have the same name or the names are the same after sanitizing.
+	item := parcon.Named("ITEM", parcon.TagStr("A", "a"))
+	parser := parcon.Sequence(
+		item,
+		parcon.Named("ITEM", parcon.TagStr("B", "b")),
+		parcon.Named("my-item", parcon.TagStr("C", "c")),
+		parcon.Named("my_item", parcon.TagStr("D", "d")),
+		item,
+	)
+
+	fmt.Print(parcon.PEG(parser))
+
+	// OUTPUT:
+	// ROOT <- ITEM ITEM_2 my_item my_item_2 ITEM
+	// ITEM <- "a"
+	// ITEM_2 <- "b"
+	// my_item <- "c"
+	// my_item_2 <- "d"
+}
@@ -0,0 +1,337 @@
+package parcon
+
+import (
+	"fmt"
+)
+
+// IndentOrder is an order of indentations for IndentGuard.
+type IndentOrder int
+
+const (
+	// IndentLess means the indentation is shallower than the current block, that is DEDENT.
+	IndentLess IndentOrder = iota
+
+	// IndentEqual means the indentation is the same as the current block.
+	IndentEqual
+
+	// IndentGreater means the indentation is deeper than the current block, that is INDENT.
+	IndentGreater
+)
+
+// String returns the name of the order like "INDENT".
+func (o IndentOrder) String() string {
+	switch o {
+	case IndentLess:
+		return "DEDENT"
+	case IndentEqual:
+		return "ALIGNED"
+	case IndentGreater:
+		return "INDENT"
+	default:
+		return fmt.Sprintf("IndentOrder(%d)", int(o))
+	}
+}
+
+// mixedIndentation is the expected thing of the error when tabs and spaces are mixed.
+const mixedIndentation = "indentation without mixing tabs and spaces"
+
+// lineIndent returns the indentation of the line that includes `input`.
+// It returns nil if `input` is not a part of Session, because the beginning of the line is unknown.
+func lineIndent(input []rune) []rune {
+	s := sessionOf(input)
+	if s == nil {
+		return nil
+	}
+
+	offset := cap(s.input) - cap(input)
+	start := offset
+	for start > 0 && s.input[start-1] != '\n' && s.input[start-1] != '\r' {
+		start--
+	}
+	end := start
+	for end < offset && (s.input[end] == ' ' || s.input[end] == '\t') {
+		end++
+	}
+	return s.input[start:end]
+}
+
+// readIndent reads the indentation at the beginning of `input`.
+// It fails with ErrCommitted if the indentation has both of tabs and spaces.
+func readIndent(input []rune) (indent, remain []rune, err error) {
+	n := 0
+	for n < len(input) && (input[n] == ' ' || input[n] == '\t') {
+		n++
+	}
+	if n == len(input) {
+		reachEnd(input)
+	}
+
+	for i := 1; i < n; i++ {
+		if input[i] != input[0] {
+			return nil, input, ErrCommitted{newError(mixedIndentation, input[i:], true)}
+		}
+	}
+	return input[:n], input[n:], nil
+}
+
+// compareIndent compares `indent` with `level`.
+// If they are inconsistent, like a tab and spaces, it returns the index of the first different character as `mismatch`, otherwise -1.
+func compareIndent(indent, level []rune) (order IndentOrder, mismatch int) {
+	n := len(indent)
+	if len(level) < n {
+		n = len(level)
+	}
+	for i := 0; i < n; i++ {
+		if indent[i] != level[i] {
+			return 0, i
+		}
+	}
+
+	switch {
+	case len(indent) < len(level):
+		return IndentLess, -1
+	case len(indent) > len(level):
+		return IndentGreater, -1
+	default:
+		return IndentEqual, -1
+	}
+}
+
+// nextLine skips newlines and blank lines, and returns the next line that starts with its indentation.
+// It returns false if `input` does not start with a newline, or if there is no more line that is not blank.
+func nextLine(input []rune) (line []rune, ok bool) {
+	line = input
+	for {
+		if len(line) == 0 {
+			reachEnd(line)
+			return input, false
+		}
+		if line[0] != '\n' && line[0] != '\r' {
+			return input, false
+		}
+		for len(line) > 0 && (line[0] == '\n' || line[0] == '\r') {
+			line = line[1:]
+		}
+
+		n := 0
+		for n < len(line) && (line[n] == ' ' || line[n] == '\t') {
+			n++
+		}
+		if n == len(line) {
+			reachEnd(line)
+			return input, false
+		}
+		if line[n] != '\n' && line[n] != '\r' {
+			return line, true
+		}
+		line = line[n:]
+	}
+}
+
+// alignedItems parses one or more `item`s that are indented by `level`.
+// The first item is parsed at `input`, and the others are parsed after a newline and the indentation.
+func alignedItems[O any](item Parser[rune, O], input, level []rune, verbose bool) (output []O, remain []rune, err error) {
+	s := sessionOf(input)
+	s.pushIndent(string(level))
+	defer s.popIndent()
+
+	o, remain, err := item.Parse(input, verbose)
+	if err != nil {
+		return
+	}
+	if err = s.emit(); err != nil {
+		return
+	}
+	output = append(output, o)
+
+	for {
+		if err = s.step(); err != nil {
+			return
+		}
+
+		line, ok := nextLine(remain)
+		if !ok {
+			return output, remain, nil
+		}
+		indent, rest, err := readIndent(line)
+		if err != nil {
+			return output, remain, err
+		}
+
+		switch order, mismatch := compareIndent(indent, level); {
+		case mismatch >= 0:
+			return output, remain, ErrCommitted{newError(mixedIndentation, line[mismatch:], true)}
+		case order == IndentLess:
+			// The end of the block. The newline is left for the outer block.
+			return output, remain, nil
+		case order == IndentGreater:
+			return output, remain, ErrCommitted{newError(ExpectedSet{IndentEqual, IndentLess}, line[len(level):], true)}
+		}
+
+		// The line is a part of this block, so the item must be parsed.
+		cp := s.checkpoint()
+		o, r, err := item.Parse(rest, verbose)
+		if err != nil {
+			if isFatal(err) {
+				return output, remain, err
+			}
+			if !verbose {
+				s.rollback(cp)
+				_, _, err = item.Parse(rest, true)
+			}
+			err = s.explain(err, cp, func() {
+				item.Parse(rest, true)
+			})
+			return output, remain, ErrCommitted{err}
+		}
+		if err = s.emit(); err != nil {
+			return output, remain, err
+		}
+		output = append(output, o)
+		remain = r
+	}
+}
+
+type alignedParser[O any] struct {
+	Item Parser[rune, O]
+}
+
+// Aligned parses one or more `item`s that are at the same indentation, separated by newlines.
+//
+// The first item is parsed at the current position, and the indentation of the current line is used as the indentation of the block.
+// The block ends before a line that is shallower than the block.
+// Blank lines are skipped, and a line that is deeper than the block is an error.
+//
+// It fails with ErrCommitted if the indentation has both of tabs and spaces, or if an item at the same indentation is broken.
+// Aligned and IndentBlock need to be used in a Session like ParseString, to know the indentation of the current line.
+func Aligned[O any](item Parser[rune, O]) Parser[rune, []O] {
+	return alignedParser[O]{item}
+}
+
+func (a alignedParser[O]) Parse(input []rune, verbose bool) (output []O, remain []rune, err error) {
+	if tracing() {
+		defer trace("Aligned", a, input).leave(&remain, &err)
+	}
+
+	return alignedItems(a.Item, input, lineIndent(input), verbose)
+}
+
+func (a alignedParser[O]) String() string {
+	return fmt.Sprintf("aligned [%v]", a.Item)
+}
+
+func (a alignedParser[O]) Kind() Kind {
+	return KindAligned
+}
+
+func (a alignedParser[O]) Children() []Node {
+	return nodesOf(a.Item)
+}
+
+type indentBlockParser[H, O any] struct {
+	Header Parser[rune, H]
+	Item   Parser[rune, O]
+}
+
+// IndentBlock parses a block like Python or YAML, that is `header` and one or more `item`s in the following lines.
+//
+// The items have to be indented deeper than the line of the header, and aligned as the same as Aligned.
+// For example, you can parse a block like below using `IndentBlock(WithSuffix(name, colon), value)`.
+//
+//	items:
+//	  first
+//	  second
+//
+// The item can include other IndentBlock for nested blocks.
+// It fails if no indented line follows the header, and fails with ErrCommitted if the indentation has both of tabs and spaces.
+func IndentBlock[H, O any](header Parser[rune, H], item Parser[rune, O]) Parser[rune, PairValue[H, []O]] {
+	return indentBlockParser[H, O]{header, item}
+}
+
+func (b indentBlockParser[H, O]) Parse(input []rune, verbose bool) (output PairValue[H, []O], remain []rune, err error) {
+	if tracing() {
+		defer trace("IndentBlock", b, input).leave(&remain, &err)
+	}
+
+	level := lineIndent(input)
+
+	output.First, remain, err = b.Header.Parse(input, verbose)
+	if err != nil {
+		return
+	}
+
+	line, ok := nextLine(remain)
+	if !ok {
+		return output, remain, newError(IndentGreater, remain, verbose)
+	}
+	indent, rest, err := readIndent(line)
+	if err != nil {
+		return
+	}
+	if order, mismatch := compareIndent(indent, level); mismatch >= 0 {
+		return output, remain, ErrCommitted{newError(mixedIndentation, line[mismatch:], true)}
+	} else if order != IndentGreater {
+		return output, remain, newError(IndentGreater, rest, verbose)
+	}
+
+	output.Second, remain, err = alignedItems(b.Item, rest, indent, verbose)
+	return
+}
+
+func (b indentBlockParser[H, O]) String() string {
+	return fmt.Sprintf("[%v] with indented block of [%v]", b.Header, b.Item)
+}
+
+func (b indentBlockParser[H, O]) Kind() Kind {
+	return KindIndentBlock
+}
+
+func (b indentBlockParser[H, O]) Children() []Node {
+	return nodesOf(b.Header, b.Item)
+}
+
+type indentGuardParser struct {
+	Order IndentOrder
+}
+
+// IndentGuard parses the indentation at the beginning of a line, and checks that it is in the `order` to the indentation of the current block.
+// The current block is the innermost Aligned or IndentBlock, or no indentation if there is no block.
+// The output is the indentation.
+//
+// It is useful to write a custom layout, like `parcon.WithPrefix(parcon.IndentGuard(parcon.IndentGreater), continuation)`.
+// It fails with ErrCommitted if the indentation has both of tabs and spaces.
+func IndentGuard(order IndentOrder) Parser[rune, string] {
+	return indentGuardParser{order}
+}
+
+func (g indentGuardParser) Parse(input []rune, verbose bool) (output string, remain []rune, err error) {
+	if tracing() {
+		defer trace("IndentGuard", g, input).leave(&remain, &err)
+	}
+
+	indent, remain, err := readIndent(input)
+	if err != nil {
+		return
+	}
+
+	order, mismatch := compareIndent(indent, []rune(sessionOf(input).currentIndent()))
+	if mismatch >= 0 {
+		return "", input, ErrCommitted{newError(mixedIndentation, input[mismatch:], true)}
+	}
+	if order != g.Order {
+		return "", input, newError(g, remain, verbose)
+	}
+	return string(indent), remain, nil
+}
+
+func (g indentGuardParser) String() string {
+	return g.Order.String()
+}
+
+func (g indentGuardParser) Kind() Kind {
+	return KindIndentGuard
+}
+
+func (g indentGuardParser) Children() []Node {
+	return nil
+}
@@ -0,0 +1,60 @@
+package parcon_test
+
+import (
+	"fmt"
+
+	"github.com/macrat/parcon"
+)
+
+func ExampleIndentBlock() {
+	key := parcon.Convert(parcon.MultiAlphas, parcon.ToString)
+
+	var item parcon.Ref[rune, any]
+	item.Set(parcon.Or(
+		parcon.Convert(parcon.IndentBlock[string, any](parcon.WithSuffix(key, parcon.TagStr("COLON", ":")), &item), func(p parcon.PairValue[string, []any]) (any, error) {
+			return map[string][]any{p.First: p.Second}, nil
+		}),
+		parcon.Convert(key, func(s string) (any, error) {
+			return s, nil
+		}),
+	))
+	parser := parcon.WithSuffix(parcon.Aligned[any](&item), parcon.Optional(parcon.MultiSpacesOrNewlines))
+
+	fmt.Println(parcon.ParseString(parser, "fruits:\n  apple\n  citrus:\n    lemon\n    orange\n\n  grape\nvegetables:\n  carrot\n"))
+
+	_, err := parcon.ParseString(parser, "fruits:\n  apple\n    lemon", parcon.Verbose(true))
+	fmt.Println(err)
+
+	_, err = parcon.ParseString(parser, "fruits:\n  apple\n\t\tlemon", parcon.Verbose(true))
+	fmt.Println(err)
+
+	_, err = parcon.ParseString(parser, "fruits:\n  apple\n  123", parcon.Verbose(false))
+	fmt.Println(err)
+
+	// OUTPUT:
+	// [map[fruits:[apple map[citrus:[lemon orange]] grape]] map[vegetables:[carrot]]] <nil>
+	// invalid input at 3:3: expected ALIGNED or DEDENT but got " "
+	// invalid input at 3:1: expected indentation without mixing tabs and spaces but got "\t"
+	// invalid input at 3:3: expected ALPHA but got "1"
+}
+
+func ExampleIndentGuard() {
+	word := parcon.Convert(parcon.MultiAlphas, parcon.ToString)
+	continuation := parcon.WithPrefix(
+		parcon.SingleNewline,
+		parcon.WithPrefix(parcon.IndentGuard(parcon.IndentGreater), word),
+	)
+	line := parcon.Convert(
+		parcon.Pair(word, parcon.Many(0, continuation)),
+		func(p parcon.PairValue[string, []string]) ([]string, error) {
+			return append([]string{p.First}, p.Second...), nil
+		},
+	)
+
+	fmt.Println(parcon.ParseString(line, "hello\n  world\n  again"))
+	fmt.Println(parcon.ParseString(parcon.WithSuffix(line, parcon.Optional(parcon.SingleNewline)), "hello\nworld"))
+
+	// OUTPUT:
+	// [hello world again] <nil>
+	// [] invalid input at 2:1: expected INDENT or EOF but got "w"
+}
@@ -0,0 +1,142 @@
+package parcon
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Kind is a kind of parser, like "Or" or "Sequence".
+type Kind string
+
+// Kinds of parsers in this package.
+const (
+	KindTag              Kind = "Tag"
+	KindOneOf            Kind = "OneOf"
+	KindOneOfList        Kind = "OneOfList"
+	KindNoneOf           Kind = "NoneOf"
+	KindNoneOfList       Kind = "NoneOfList"
+	KindAnything         Kind = "Anything"
+	KindNothing          Kind = "Nothing"
+	KindTakeSingle       Kind = "TakeSingle"
+	KindTakeWhile        Kind = "TakeWhile"
+	KindEOF              Kind = "EOF"
+	KindOptional         Kind = "Optional"
+	KindOr               Kind = "Or"
+	KindSequence         Kind = "Sequence"
+	KindPair             Kind = "Pair"
+	KindWithEnclosure    Kind = "WithEnclosure"
+	KindMany             Kind = "Many"
+	KindSeparatedList    Kind = "SeparatedList"
+	KindConvert          Kind = "Convert"
+	KindConvertWithState Kind = "ConvertWithState"
+	KindMatchOnly        Kind = "MatchOnly"
+	KindReplace          Kind = "Replace"
+	KindNamed            Kind = "Named"
+	KindRef              Kind = "Ref"
+	KindLazy             Kind = "Lazy"
+	KindMemo             Kind = "Memo"
+	KindPeek             Kind = "Peek"
+	KindNot              Kind = "Not"
+	KindCommit           Kind = "Commit"
+	KindRecover          Kind = "Recover"
+	KindExpression       Kind = "Expression"
+	KindWithSpan         Kind = "WithSpan"
+	KindRegexp           Kind = "Regexp"
+	KindToken            Kind = "Token"
+	KindAligned          Kind = "Aligned"
+	KindIndentBlock      Kind = "IndentBlock"
+	KindIndentGuard      Kind = "IndentGuard"
+
+	// KindCustom is a kind of parsers that not made by this package, like ParserFunc.
+	KindCustom Kind = "Custom"
+)
+
+// Node is an interface to inspect the structure of parsers.
+// All parsers in this package implement it, except for ParserFunc.
+type Node interface {
+	// String returns the name of the parser.
+	String() string
+
+	// Kind returns the kind of the parser.
+	Kind() Kind
+
+	// Children returns the parsers that used by the parser.
+	Children() []Node
+}
+
+// LiteralNode is a Node that has a literal value, like Tag or OneOf.
+type LiteralNode interface {
+	Node
+
+	// Literal returns the literal value as a slice of the input type, like the tag of Tag or the list of OneOf.
+	Literal() any
+}
+
+// RepeatNode is a Node that repeats a parser, like Many or SeparatedList.
+type RepeatNode interface {
+	Node
+
+	// Bounds returns the minimum and the maximum number of repeats.
+	// The maximum is 0 if there is no limit.
+	Bounds() (min, max uint)
+}
+
+type customNode struct {
+	Parser any
+}
+
+func (c customNode) String() string {
+	return fmt.Sprint(c.Parser)
+}
+
+func (c customNode) Kind() Kind {
+	return KindCustom
+}
+
+func (c customNode) Children() []Node {
+	return nil
+}
+
+// NodeOf returns the Node of `parser`.
+// If `parser` does not implement Node, it returns a Node that has KindCustom and no children.
+func NodeOf(parser any) Node {
+	if n, ok := parser.(Node); ok {
+		return n
+	}
+	return customNode{parser}
+}
+
+// nodesOf returns Nodes of `parsers`.
+func nodesOf(parsers ...any) []Node {
+	ns := make([]Node, len(parsers))
+	for i, p := range parsers {
+		ns[i] = NodeOf(p)
+	}
+	return ns
+}
+
+// Walk calls `fn` for `parser` and its descendants in depth-first order.
+// If `fn` returns false, Walk does not visit the children of the node.
+//
+// Walk visits the children of pointer parsers like Ref only once, to avoid infinite loop in recursive grammars.
+// Please notice that Walk makes the parser of Lazy.
+func Walk(parser any, fn func(node Node) bool) {
+	walk(NodeOf(parser), fn, make(map[Node]bool))
+}
+
+func walk(node Node, fn func(Node) bool, visited map[Node]bool) {
+	if !fn(node) {
+		return
+	}
+
+	if reflect.ValueOf(node).Kind() == reflect.Pointer {
+		if visited[node] {
+			return
+		}
+		visited[node] = true
+	}
+
+	for _, c := range node.Children() {
+		walk(c, fn, visited)
+	}
+}
@@ -0,0 +1,75 @@
+package parcon_test
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/macrat/parcon"
+)
+
+func ExampleWalk() {
+	var ref parcon.Ref[rune, []string]
+	var list parcon.Parser[rune, []string] = &ref
+
+	word := parcon.Named("WORD", parcon.Convert(parcon.MultiAlphas, parcon.ToString))
+	ref.Set(parcon.Named("LIST", parcon.WithEnclosure(
+		parcon.TagStr("BEGIN", "("),
+		parcon.SeparatedList(0, parcon.TagStr("SPACE", " "), parcon.Or(
+			word,
+			parcon.Convert(list, func(xs []string) (string, error) {
+				return strings.Join(xs, "+"), nil
+			}),
+		)),
+		parcon.TagStr("END", ")"),
+	)))
+
+	// Find all tags in the grammar.
+	parcon.Walk(list, func(node parcon.Node) bool {
+		if n, ok := node.(parcon.LiteralNode); ok && node.Kind() == parcon.KindTag {
+			fmt.Printf("%s: %q\n", node, string(n.Literal().([]rune)))
+		}
+		return true
+	})
+
+	// OUTPUT:
+	// BEGIN: "("
+	// SPACE: " "
+	// END: ")"
+}
+
+func ExampleNodeOf() {
+	parser := parcon.Or(
+		parcon.Named("GREETING", parcon.Sequence(
+			parcon.TagStr("HELLO", "hello"),
+			parcon.TagStr("WORLD", "world"),
+		)),
+		parcon.Many(1, parcon.TagStr("BANG", "!")),
+	)
+
+	var show func(node parcon.Node, depth int)
+	show = func(node parcon.Node, depth int) {
+		fmt.Printf("%s%s", strings.Repeat("  ", depth), node.Kind())
+		if n, ok := node.(parcon.RepeatNode); ok {
+			min, max := n.Bounds()
+			fmt.Printf(" min=%d max=%d", min, max)
+		}
+		if node.Kind() == parcon.KindNamed || node.Kind() == parcon.KindTag {
+			fmt.Printf(" %s", node)
+		}
+		fmt.Println()
+
+		for _, c := range node.Children() {
+			show(c, depth+1)
+		}
+	}
+	show(parcon.NodeOf(parser), 0)
+
+	// OUTPUT:
+	// Or
+	//   Named GREETING
+	//     Sequence
+	//       Tag HELLO
+	//       Tag WORLD
+	//   Many min=1 max=0
+	//     Tag BANG
+}
@@ -0,0 +1,345 @@
+// Code generated by parcon-gen. DO NOT EDIT.
+
+package gentest
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// ParseChoice parses the whole of input, and returns the output of the rule value.
+// The actions are called by the names in the grammar.
+func ParseChoice(input string, actions map[string]func(any) (any, error)) (any, error) {
+	p := ChoiceParser{input: input}
+	for i, name := range [2]string{"number", "word"} {
+		fn, ok := actions[name]
+		if !ok {
+			return nil, fmt.Errorf("undefined action %q", name)
+		}
+		p.actions[i] = fn
+	}
+
+	output, pos, ok := p.rule_value(0)
+	if p.err != nil {
+		return nil, p.err
+	}
+	if ok && pos == len(input) {
+		return output, nil
+	}
+	if ok {
+		p.fail(pos, "EOF")
+	}
+	return nil, p.error()
+}
+
+// ChoiceParser parses input into the syntax tree of rules, without allocation once its buffers grown enough.
+// The zero value is ready to use, and it can be reused to parse other inputs, but not from multiple goroutines at the same time.
+type ChoiceParser struct {
+	input   string
+	actions [2]func(any) (any, error)
+	err     error
+
+	// silent is greater than 0 while parsing in a negative lookahead, that does not report errors.
+	silent int
+
+	// failPos is the furthest position that failed, and expected is things expected at there.
+	failPos  int
+	expected []string
+
+	// nodes is the syntax tree that made while matching.
+	nodes []ChoiceNode
+}
+
+// ChoiceNode is a node of the syntax tree that made by ChoiceParser.
+// It is the span of a rule in the input.
+type ChoiceNode struct {
+	Rule       string
+	Start, End int
+
+	// Size is the number of nodes in the subtree including this node.
+	// The children follow this node, and the next sibling is at the index plus Size.
+	Size int
+}
+
+// Parse parses the whole of input, and returns the syntax tree of rules in pre-order.
+// It does not call actions.
+// The nodes are valid until the next call of Parse.
+func (p *ChoiceParser) Parse(input string) ([]ChoiceNode, error) {
+	p.input, p.err, p.silent, p.failPos = input, nil, 0, 0
+	p.expected, p.nodes = p.expected[:0], p.nodes[:0]
+
+	_, pos, ok := p.match_value(0)
+	if ok && pos == len(input) {
+		return p.nodes, nil
+	}
+	if ok {
+		p.fail(pos, "EOF")
+	}
+	return nil, p.error()
+}
+
+// fail records that expected is not found at pos.
+func (p *ChoiceParser) fail(pos int, expected string) {
+	if p.silent > 0 || pos < p.failPos {
+		return
+	}
+	if pos > p.failPos {
+		p.failPos = pos
+		p.expected = p.expected[:0]
+	}
+	for _, e := range p.expected {
+		if e == expected {
+			return
+		}
+	}
+	p.expected = append(p.expected, expected)
+}
+
+// error makes an error at the furthest position that failed.
+func (p *ChoiceParser) error() error {
+	line, column := 1, 1
+	for _, c := range p.input[:p.failPos] {
+		if c == '\n' {
+			line++
+			column = 1
+		} else {
+			column++
+		}
+	}
+
+	got := "end of input"
+	if p.failPos < len(p.input) {
+		c, _ := utf8.DecodeRuneInString(p.input[p.failPos:])
+		got = fmt.Sprintf("%q", string(c))
+	}
+
+	var expected string
+	switch n := len(p.expected); n {
+	case 0:
+		expected = "nothing"
+	case 1:
+		expected = p.expected[0]
+	case 2:
+		expected = p.expected[0] + " or " + p.expected[1]
+	default:
+		expected = strings.Join(p.expected[:n-1], ", ") + ", or " + p.expected[n-1]
+	}
+
+	return fmt.Errorf("invalid input at %d:%d: expected %s but got %s", line, column, expected, got)
+}
+
+// rule_value parses number / word
+func (p *ChoiceParser) rule_value(pos int) (any, int, bool) {
+	if output, end, ok := p.rule_number(pos); ok {
+		return output, end, true
+	}
+	if p.err != nil {
+		return nil, pos, false
+	}
+	if output, end, ok := p.rule_word(pos); ok {
+		return output, end, true
+	}
+	if p.err != nil {
+		return nil, pos, false
+	}
+	return nil, pos, false
+}
+
+// match3 matches [0-9]
+func (p *ChoiceParser) match3(pos int) (any, int, bool) {
+	if c, n := utf8.DecodeRuneInString(p.input[pos:]); n > 0 && '0' <= c && c <= '9' {
+		return nil, pos + n, true
+	}
+	p.fail(pos, "[0-9]")
+	return nil, pos, false
+}
+
+// match2 matches [0-9]+
+func (p *ChoiceParser) match2(pos int) (any, int, bool) {
+	count := 0
+	for {
+		n := len(p.nodes)
+		_, end, ok := p.match3(pos)
+		if !ok || (count > 0 && end == pos) {
+			p.nodes = p.nodes[:n]
+			break
+		}
+		count++
+		pos = end
+	}
+	if count == 0 {
+		return nil, pos, false
+	}
+	return nil, pos, true
+}
+
+// expr1 parses $[0-9]+
+func (p *ChoiceParser) expr1(pos int) (any, int, bool) {
+	if _, end, ok := p.match2(pos); ok {
+		return p.input[pos:end], end, true
+	}
+	return nil, pos, false
+}
+
+// rule_number parses $[0-9]+ {number}
+func (p *ChoiceParser) rule_number(pos int) (any, int, bool) {
+	start := pos
+	v0, pos, ok := p.expr1(pos)
+	if !ok {
+		return nil, start, false
+	}
+	output, err := p.actions[0](v0)
+	if err != nil {
+		if p.err == nil {
+			p.err = err
+		}
+		return nil, pos, false
+	}
+	return output, pos, true
+}
+
+// match6 matches [0-9a-z]
+func (p *ChoiceParser) match6(pos int) (any, int, bool) {
+	if c, n := utf8.DecodeRuneInString(p.input[pos:]); n > 0 && ('0' <= c && c <= '9' || 'a' <= c && c <= 'z') {
+		return nil, pos + n, true
+	}
+	p.fail(pos, "[0-9a-z]")
+	return nil, pos, false
+}
+
+// match5 matches [0-9a-z]+
+func (p *ChoiceParser) match5(pos int) (any, int, bool) {
+	count := 0
+	for {
+		n := len(p.nodes)
+		_, end, ok := p.match6(pos)
+		if !ok || (count > 0 && end == pos) {
+			p.nodes = p.nodes[:n]
+			break
+		}
+		count++
+		pos = end
+	}
+	if count == 0 {
+		return nil, pos, false
+	}
+	return nil, pos, true
+}
+
+// expr4 parses $[0-9a-z]+
+func (p *ChoiceParser) expr4(pos int) (any, int, bool) {
+	if _, end, ok := p.match5(pos); ok {
+		return p.input[pos:end], end, true
+	}
+	return nil, pos, false
+}
+
+// rule_word parses $[0-9a-z]+ {word}
+func (p *ChoiceParser) rule_word(pos int) (any, int, bool) {
+	start := pos
+	v0, pos, ok := p.expr4(pos)
+	if !ok {
+		return nil, start, false
+	}
+	output, err := p.actions[1](v0)
+	if err != nil {
+		if p.err == nil {
+			p.err = err
+		}
+		return nil, pos, false
+	}
+	return output, pos, true
+}
+
+// match9 matches $[0-9]+
+func (p *ChoiceParser) match9(pos int) (any, int, bool) {
+	if _, end, ok := p.match2(pos); ok {
+		return nil, end, true
+	}
+	return nil, pos, false
+}
+
+// match8 matches $[0-9]+ {number}
+func (p *ChoiceParser) match8(pos int) (any, int, bool) {
+	start := pos
+	n := len(p.nodes)
+	var ok bool
+	_, pos, ok = p.match9(pos)
+	if !ok {
+		p.nodes = p.nodes[:n]
+		return nil, start, false
+	}
+	return nil, pos, true
+}
+
+// match_number matches the rule number, and records it as a node.
+func (p *ChoiceParser) match_number(pos int) (any, int, bool) {
+	i := len(p.nodes)
+	p.nodes = append(p.nodes, ChoiceNode{Rule: "number", Start: pos})
+	_, end, ok := p.match8(pos)
+	if !ok {
+		p.nodes = p.nodes[:i]
+		return nil, pos, false
+	}
+	p.nodes[i].End, p.nodes[i].Size = end, len(p.nodes)-i
+	return nil, end, true
+}
+
+// match11 matches $[0-9a-z]+
+func (p *ChoiceParser) match11(pos int) (any, int, bool) {
+	if _, end, ok := p.match5(pos); ok {
+		return nil, end, true
+	}
+	return nil, pos, false
+}
+
+// match10 matches $[0-9a-z]+ {word}
+func (p *ChoiceParser) match10(pos int) (any, int, bool) {
+	start := pos
+	n := len(p.nodes)
+	var ok bool
+	_, pos, ok = p.match11(pos)
+	if !ok {
+		p.nodes = p.nodes[:n]
+		return nil, start, false
+	}
+	return nil, pos, true
+}
+
+// match_word matches the rule word, and records it as a node.
+func (p *ChoiceParser) match_word(pos int) (any, int, bool) {
+	i := len(p.nodes)
+	p.nodes = append(p.nodes, ChoiceNode{Rule: "word", Start: pos})
+	_, end, ok := p.match10(pos)
+	if !ok {
+		p.nodes = p.nodes[:i]
+		return nil, pos, false
+	}
+	p.nodes[i].End, p.nodes[i].Size = end, len(p.nodes)-i
+	return nil, end, true
+}
+
+// match7 matches number / word
+func (p *ChoiceParser) match7(pos int) (any, int, bool) {
+	if output, end, ok := p.match_number(pos); ok {
+		return output, end, true
+	}
+	if output, end, ok := p.match_word(pos); ok {
+		return output, end, true
+	}
+	return nil, pos, false
+}
+
+// match_value matches the rule value, and records it as a node.
+func (p *ChoiceParser) match_value(pos int) (any, int, bool) {
+	i := len(p.nodes)
+	p.nodes = append(p.nodes, ChoiceNode{Rule: "value", Start: pos})
+	_, end, ok := p.match7(pos)
+	if !ok {
+		p.nodes = p.nodes[:i]
+		return nil, pos, false
+	}
+	p.nodes[i].End, p.nodes[i].Size = end, len(p.nodes)-i
+	return nil, end, true
+}
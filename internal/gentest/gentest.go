@@ -0,0 +1,36 @@
+// Package gentest tests parsers generated by parcon-gen.
+package gentest
+
+import (
+	"fmt"
+	"strings"
+)
+
+//go:generate go run ../../cmd/parcon-gen -name JSON -o json_gen.go json.peg
+//go:generate go run ../../cmd/parcon-gen -name Choice -o choice_gen.go choice.peg
+//go:generate go run ../../cmd/parcon-gen -name List -o list_gen.go list.peg
+
+// SimpleList returns a comma separated list of numbers, and the number of items in it.
+// It is the input of the simple list benchmarks, that shared with the benchmarks of parcon.
+func SimpleList() (string, int) {
+	xs := make([]string, 1000)
+	for i := range xs {
+		xs[i] = fmt.Sprint(i)
+	}
+	return strings.Join(xs, ","), len(xs)
+}
+
+// SplitList splits a comma separated list by hand, without parcon.
+// It is the baseline of the simple list benchmarks.
+func SplitList(input []rune) [][]rune {
+	var result [][]rune
+	var buf []rune
+	for _, x := range input {
+		if x == ',' {
+			result = append(result, buf)
+		} else {
+			buf = append(buf, x)
+		}
+	}
+	return append(result, buf)
+}
@@ -0,0 +1,290 @@
+package gentest_test
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/macrat/parcon"
+	"github.com/macrat/parcon/internal/gentest"
+)
+
+var jsonActions = map[string]func(any) (any, error){
+	"number": func(x any) (any, error) {
+		return strconv.ParseFloat(x.(string), 64)
+	},
+}
+
+var listActions = map[string]func(any) (any, error){
+	"list": func(x any) (any, error) {
+		xs := x.([]any)
+		rest := xs[1].([]any)
+		ss := make([]string, 0, len(rest)+1)
+		ss = append(ss, xs[0].(string))
+		for _, y := range rest {
+			ss = append(ss, y.([]any)[1].(string))
+		}
+		return ss, nil
+	},
+}
+
+func compile(t testing.TB, file string, actions map[string]func(any) (any, error)) parcon.Parser[rune, any] {
+	grammar, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("failed to read grammar: %s", err)
+	}
+
+	as := make(map[string]parcon.ConvertFunc[any, any])
+	for name, fn := range actions {
+		as[name] = fn
+	}
+
+	parser, err := parcon.CompilePEG(string(grammar), as)
+	if err != nil {
+		t.Fatalf("failed to compile grammar: %s", err)
+	}
+	return parser
+}
+
+func Test_sameAsCompilePEG(t *testing.T) {
+	tests := []struct {
+		file      string
+		generated func(string, map[string]func(any) (any, error)) (any, error)
+		actions   map[string]func(any) (any, error)
+		inputs    []string
+	}{
+		{
+			"json.peg",
+			gentest.ParseJSON,
+			jsonActions,
+			[]string{
+				`{"a": [1, 2.5, -3], "b": "text", "c": true}`,
+				`[]`,
+				` { } `,
+				`[null, false, "", [[]]]`,
+				`"日本語"`,
+				`nullx`,
+				`[1,`,
+				`{"a" 1}`,
+				`"abc`,
+				``,
+			},
+		},
+		{
+			"list.peg",
+			gentest.ParseList,
+			listActions,
+			[]string{
+				`a,b,c`,
+				`a,,c`,
+				``,
+				`,`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		compiled := compile(t, tt.file, tt.actions)
+
+		for _, input := range tt.inputs {
+			t.Run(fmt.Sprintf("%s/%q", tt.file, input), func(t *testing.T) {
+				expected, expectedErr := parcon.ParseString(compiled, input)
+				actual, actualErr := tt.generated(input, tt.actions)
+
+				if (expectedErr == nil) != (actualErr == nil) {
+					t.Fatalf("unexpected error: expected %v but got %v", expectedErr, actualErr)
+				}
+				if !reflect.DeepEqual(expected, actual) {
+					t.Errorf("unexpected output:\nexpected: %#v\n but got: %#v", expected, actual)
+				}
+			})
+		}
+	}
+}
+
+func Test_errors(t *testing.T) {
+	tests := []struct {
+		input string
+		err   string
+	}{
+		{`[1,`, `invalid input at 1:4: expected [ \t\r\n], "{", "[", "\"", "-", [0-9], or [tfn] but got end of input`},
+		{"{\n  \"a\" 1}", `invalid input at 2:7: expected [ \t\r\n] or ":" but got "1"`},
+		{`nullx`, `invalid input at 1:5: expected not [[a-zA-Z0-9_]] but got "x"`},
+		{`1 2`, `invalid input at 1:3: expected [ \t\r\n] or EOF but got "2"`},
+	}
+
+	for _, tt := range tests {
+		_, err := gentest.ParseJSON(tt.input, jsonActions)
+		if err == nil {
+			t.Errorf("%q: expected error but got nil", tt.input)
+		} else if err.Error() != tt.err {
+			t.Errorf("%q: unexpected error:\nexpected: %s\n but got: %s", tt.input, tt.err, err)
+		}
+	}
+
+	_, err := gentest.ParseJSON(`1`, nil)
+	if err == nil || err.Error() != `undefined action "number"` {
+		t.Errorf("unexpected error of undefined action: %v", err)
+	}
+}
+
+func Test_actionError(t *testing.T) {
+	var called []string
+	actions := map[string]func(any) (any, error){
+		"number": func(x any) (any, error) {
+			called = append(called, "number")
+			return nil, fmt.Errorf("number is not allowed: %v", x)
+		},
+		"word": func(x any) (any, error) {
+			called = append(called, "word")
+			return x, nil
+		},
+	}
+
+	_, err := gentest.ParseChoice("123", actions)
+	if err == nil || err.Error() != "number is not allowed: 123" {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(called, []string{"number"}) {
+		t.Errorf("other alternatives are tried after the error of action: %v", called)
+	}
+}
+
+func Test_tree(t *testing.T) {
+	type node struct {
+		Rule string
+		Text string
+		Size int
+	}
+
+	var p gentest.JSONParser
+
+	nodes, err := p.Parse(`{"a": [1, null]}`)
+	if err != nil {
+		t.Fatalf("failed to parse: %s", err)
+	}
+	actual := make([]node, len(nodes))
+	for i, n := range nodes {
+		actual[i] = node{n.Rule, `{"a": [1, null]}`[n.Start:n.End], n.Size}
+	}
+
+	expected := []node{
+		{"value", `{"a": [1, null]}`, 21},
+		{"_", ``, 1},
+		{"object", `{"a": [1, null]}`, 18},
+		{"_", ``, 1},
+		{"member", `"a": [1, null]`, 16},
+		{"string", `"a"`, 1},
+		{"_", ``, 1},
+		{"value", ` [1, null]`, 13},
+		{"_", ` `, 1},
+		{"array", `[1, null]`, 10},
+		{"_", ``, 1},
+		{"value", `1`, 4},
+		{"_", ``, 1},
+		{"number", `1`, 1},
+		{"_", ``, 1},
+		{"value", ` null`, 4},
+		{"_", ` `, 1},
+		{"literal", `null`, 1},
+		{"_", ``, 1},
+		{"_", ``, 1},
+		{"_", ``, 1},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("unexpected nodes:\nexpected: %v\n but got: %v", expected, actual)
+	}
+
+	if _, err := p.Parse(`{"a" 1}`); err == nil || err.Error() != `invalid input at 1:6: expected [ \t\r\n] or ":" but got "1"` {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func Test_treeWithoutAllocation(t *testing.T) {
+	input, _ := gentest.SimpleList()
+
+	var list gentest.ListParser
+	if n := testing.AllocsPerRun(10, func() { list.Parse(input) }); n != 0 {
+		t.Errorf("ListParser allocated %v times", n)
+	}
+
+	var json gentest.JSONParser
+	if n := testing.AllocsPerRun(10, func() { json.Parse(`{"a": [1, 2.5, -3], "b": "text", "c": true}`) }); n != 0 {
+		t.Errorf("JSONParser allocated %v times", n)
+	}
+}
+
+func Benchmark_simpleListCompiled(b *testing.B) {
+	parser := compile(b, "list.peg", listActions)
+
+	input, l := gentest.SimpleList()
+	b.SetBytes(int64(len(input)))
+
+	output, err := parcon.ParseString(parser, input)
+	if err != nil {
+		b.Fatalf("failed to parse: %s", err)
+	}
+	if len(output.([]string)) != l {
+		b.Fatalf("found unexpected length of array: expected %d but got %d", l, len(output.([]string)))
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		parcon.ParseString(parser, input)
+	}
+}
+
+func Benchmark_simpleListGenerated(b *testing.B) {
+	input, l := gentest.SimpleList()
+	b.SetBytes(int64(len(input)))
+
+	output, err := gentest.ParseList(input, listActions)
+	if err != nil {
+		b.Fatalf("failed to parse: %s", err)
+	}
+	if len(output.([]string)) != l {
+		b.Fatalf("found unexpected length of array: expected %d but got %d", l, len(output.([]string)))
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		gentest.ParseList(input, listActions)
+	}
+}
+
+// Benchmark_simpleListWithoutParcon is the same as the benchmark of parcon, to compare with the generated parser.
+func Benchmark_simpleListWithoutParcon(b *testing.B) {
+	input, l := gentest.SimpleList()
+	b.SetBytes(int64(len(input)))
+
+	runes := []rune(input)
+	if output := gentest.SplitList(runes); len(output) != l {
+		b.Fatalf("found unexpected length of array: expected %d but got %d", l, len(output))
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		gentest.SplitList(runes)
+	}
+}
+
+func Benchmark_simpleListGeneratedTree(b *testing.B) {
+	input, l := gentest.SimpleList()
+	b.SetBytes(int64(len(input)))
+
+	var p gentest.ListParser
+	nodes, err := p.Parse(input)
+	if err != nil {
+		b.Fatalf("failed to parse: %s", err)
+	}
+	if len(nodes) != l+1 {
+		b.Fatalf("found unexpected number of nodes: expected %d but got %d", l+1, len(nodes))
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.Parse(input)
+	}
+}
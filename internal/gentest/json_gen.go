@@ -0,0 +1,1373 @@
+// Code generated by parcon-gen. DO NOT EDIT.
+
+package gentest
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// ParseJSON parses the whole of input, and returns the output of the rule value.
+// The actions are called by the names in the grammar.
+func ParseJSON(input string, actions map[string]func(any) (any, error)) (any, error) {
+	p := JSONParser{input: input}
+	for i, name := range [1]string{"number"} {
+		fn, ok := actions[name]
+		if !ok {
+			return nil, fmt.Errorf("undefined action %q", name)
+		}
+		p.actions[i] = fn
+	}
+
+	output, pos, ok := p.rule_value(0)
+	if p.err != nil {
+		return nil, p.err
+	}
+	if ok && pos == len(input) {
+		return output, nil
+	}
+	if ok {
+		p.fail(pos, "EOF")
+	}
+	return nil, p.error()
+}
+
+// JSONParser parses input into the syntax tree of rules, without allocation once its buffers grown enough.
+// The zero value is ready to use, and it can be reused to parse other inputs, but not from multiple goroutines at the same time.
+type JSONParser struct {
+	input   string
+	actions [1]func(any) (any, error)
+	err     error
+
+	// silent is greater than 0 while parsing in a negative lookahead, that does not report errors.
+	silent int
+
+	// failPos is the furthest position that failed, and expected is things expected at there.
+	failPos  int
+	expected []string
+
+	// nodes is the syntax tree that made while matching.
+	nodes []JSONNode
+}
+
+// JSONNode is a node of the syntax tree that made by JSONParser.
+// It is the span of a rule in the input.
+type JSONNode struct {
+	Rule       string
+	Start, End int
+
+	// Size is the number of nodes in the subtree including this node.
+	// The children follow this node, and the next sibling is at the index plus Size.
+	Size int
+}
+
+// Parse parses the whole of input, and returns the syntax tree of rules in pre-order.
+// It does not call actions.
+// The nodes are valid until the next call of Parse.
+func (p *JSONParser) Parse(input string) ([]JSONNode, error) {
+	p.input, p.err, p.silent, p.failPos = input, nil, 0, 0
+	p.expected, p.nodes = p.expected[:0], p.nodes[:0]
+
+	_, pos, ok := p.match_value(0)
+	if ok && pos == len(input) {
+		return p.nodes, nil
+	}
+	if ok {
+		p.fail(pos, "EOF")
+	}
+	return nil, p.error()
+}
+
+// fail records that expected is not found at pos.
+func (p *JSONParser) fail(pos int, expected string) {
+	if p.silent > 0 || pos < p.failPos {
+		return
+	}
+	if pos > p.failPos {
+		p.failPos = pos
+		p.expected = p.expected[:0]
+	}
+	for _, e := range p.expected {
+		if e == expected {
+			return
+		}
+	}
+	p.expected = append(p.expected, expected)
+}
+
+// error makes an error at the furthest position that failed.
+func (p *JSONParser) error() error {
+	line, column := 1, 1
+	for _, c := range p.input[:p.failPos] {
+		if c == '\n' {
+			line++
+			column = 1
+		} else {
+			column++
+		}
+	}
+
+	got := "end of input"
+	if p.failPos < len(p.input) {
+		c, _ := utf8.DecodeRuneInString(p.input[p.failPos:])
+		got = fmt.Sprintf("%q", string(c))
+	}
+
+	var expected string
+	switch n := len(p.expected); n {
+	case 0:
+		expected = "nothing"
+	case 1:
+		expected = p.expected[0]
+	case 2:
+		expected = p.expected[0] + " or " + p.expected[1]
+	default:
+		expected = strings.Join(p.expected[:n-1], ", ") + ", or " + p.expected[n-1]
+	}
+
+	return fmt.Errorf("invalid input at %d:%d: expected %s but got %s", line, column, expected, got)
+}
+
+// expr1 parses object / array / string / number / literal
+func (p *JSONParser) expr1(pos int) (any, int, bool) {
+	if output, end, ok := p.rule_object(pos); ok {
+		return output, end, true
+	}
+	if p.err != nil {
+		return nil, pos, false
+	}
+	if output, end, ok := p.rule_array(pos); ok {
+		return output, end, true
+	}
+	if p.err != nil {
+		return nil, pos, false
+	}
+	if output, end, ok := p.rule_string(pos); ok {
+		return output, end, true
+	}
+	if p.err != nil {
+		return nil, pos, false
+	}
+	if output, end, ok := p.rule_number(pos); ok {
+		return output, end, true
+	}
+	if p.err != nil {
+		return nil, pos, false
+	}
+	if output, end, ok := p.rule_literal(pos); ok {
+		return output, end, true
+	}
+	if p.err != nil {
+		return nil, pos, false
+	}
+	return nil, pos, false
+}
+
+// rule_value parses _ (object / array / string / number / literal) _
+func (p *JSONParser) rule_value(pos int) (any, int, bool) {
+	start := pos
+	v0, pos, ok := p.rule__(pos)
+	if !ok {
+		return nil, start, false
+	}
+	v1, pos, ok := p.expr1(pos)
+	if !ok {
+		return nil, start, false
+	}
+	v2, pos, ok := p.rule__(pos)
+	if !ok {
+		return nil, start, false
+	}
+	return []any{v0, v1, v2}, pos, true
+}
+
+// expr2 parses "{"
+func (p *JSONParser) expr2(pos int) (any, int, bool) {
+	if strings.HasPrefix(p.input[pos:], "{") {
+		return "{", pos + 1, true
+	}
+	p.fail(pos, "\"{\"")
+	return nil, pos, false
+}
+
+// expr7 parses ","
+func (p *JSONParser) expr7(pos int) (any, int, bool) {
+	if strings.HasPrefix(p.input[pos:], ",") {
+		return ",", pos + 1, true
+	}
+	p.fail(pos, "\",\"")
+	return nil, pos, false
+}
+
+// expr6 parses "," _ member
+func (p *JSONParser) expr6(pos int) (any, int, bool) {
+	start := pos
+	v0, pos, ok := p.expr7(pos)
+	if !ok {
+		return nil, start, false
+	}
+	v1, pos, ok := p.rule__(pos)
+	if !ok {
+		return nil, start, false
+	}
+	v2, pos, ok := p.rule_member(pos)
+	if !ok {
+		return nil, start, false
+	}
+	return []any{v0, v1, v2}, pos, true
+}
+
+// expr5 parses ("," _ member)*
+func (p *JSONParser) expr5(pos int) (any, int, bool) {
+	outputs := []any{}
+	for {
+		output, end, ok := p.expr6(pos)
+		if !ok || (len(outputs) > 0 && end == pos) {
+			break
+		}
+		outputs = append(outputs, output)
+		pos = end
+	}
+	if p.err != nil {
+		return nil, pos, false
+	}
+	return outputs, pos, true
+}
+
+// expr4 parses member ("," _ member)*
+func (p *JSONParser) expr4(pos int) (any, int, bool) {
+	start := pos
+	v0, pos, ok := p.rule_member(pos)
+	if !ok {
+		return nil, start, false
+	}
+	v1, pos, ok := p.expr5(pos)
+	if !ok {
+		return nil, start, false
+	}
+	return []any{v0, v1}, pos, true
+}
+
+// expr3 parses (member ("," _ member)*)?
+func (p *JSONParser) expr3(pos int) (any, int, bool) {
+	if output, end, ok := p.expr4(pos); ok {
+		return output, end, true
+	}
+	if p.err != nil {
+		return nil, pos, false
+	}
+	return nil, pos, true
+}
+
+// expr8 parses "}"
+func (p *JSONParser) expr8(pos int) (any, int, bool) {
+	if strings.HasPrefix(p.input[pos:], "}") {
+		return "}", pos + 1, true
+	}
+	p.fail(pos, "\"}\"")
+	return nil, pos, false
+}
+
+// rule_object parses "{" _ (member ("," _ member)*)? "}"
+func (p *JSONParser) rule_object(pos int) (any, int, bool) {
+	start := pos
+	v0, pos, ok := p.expr2(pos)
+	if !ok {
+		return nil, start, false
+	}
+	v1, pos, ok := p.rule__(pos)
+	if !ok {
+		return nil, start, false
+	}
+	v2, pos, ok := p.expr3(pos)
+	if !ok {
+		return nil, start, false
+	}
+	v3, pos, ok := p.expr8(pos)
+	if !ok {
+		return nil, start, false
+	}
+	return []any{v0, v1, v2, v3}, pos, true
+}
+
+// expr9 parses ":"
+func (p *JSONParser) expr9(pos int) (any, int, bool) {
+	if strings.HasPrefix(p.input[pos:], ":") {
+		return ":", pos + 1, true
+	}
+	p.fail(pos, "\":\"")
+	return nil, pos, false
+}
+
+// rule_member parses string _ ":" value
+func (p *JSONParser) rule_member(pos int) (any, int, bool) {
+	start := pos
+	v0, pos, ok := p.rule_string(pos)
+	if !ok {
+		return nil, start, false
+	}
+	v1, pos, ok := p.rule__(pos)
+	if !ok {
+		return nil, start, false
+	}
+	v2, pos, ok := p.expr9(pos)
+	if !ok {
+		return nil, start, false
+	}
+	v3, pos, ok := p.rule_value(pos)
+	if !ok {
+		return nil, start, false
+	}
+	return []any{v0, v1, v2, v3}, pos, true
+}
+
+// expr10 parses "["
+func (p *JSONParser) expr10(pos int) (any, int, bool) {
+	if strings.HasPrefix(p.input[pos:], "[") {
+		return "[", pos + 1, true
+	}
+	p.fail(pos, "\"[\"")
+	return nil, pos, false
+}
+
+// expr15 parses ","
+func (p *JSONParser) expr15(pos int) (any, int, bool) {
+	if strings.HasPrefix(p.input[pos:], ",") {
+		return ",", pos + 1, true
+	}
+	p.fail(pos, "\",\"")
+	return nil, pos, false
+}
+
+// expr14 parses "," value
+func (p *JSONParser) expr14(pos int) (any, int, bool) {
+	start := pos
+	v0, pos, ok := p.expr15(pos)
+	if !ok {
+		return nil, start, false
+	}
+	v1, pos, ok := p.rule_value(pos)
+	if !ok {
+		return nil, start, false
+	}
+	return []any{v0, v1}, pos, true
+}
+
+// expr13 parses ("," value)*
+func (p *JSONParser) expr13(pos int) (any, int, bool) {
+	outputs := []any{}
+	for {
+		output, end, ok := p.expr14(pos)
+		if !ok || (len(outputs) > 0 && end == pos) {
+			break
+		}
+		outputs = append(outputs, output)
+		pos = end
+	}
+	if p.err != nil {
+		return nil, pos, false
+	}
+	return outputs, pos, true
+}
+
+// expr12 parses value ("," value)*
+func (p *JSONParser) expr12(pos int) (any, int, bool) {
+	start := pos
+	v0, pos, ok := p.rule_value(pos)
+	if !ok {
+		return nil, start, false
+	}
+	v1, pos, ok := p.expr13(pos)
+	if !ok {
+		return nil, start, false
+	}
+	return []any{v0, v1}, pos, true
+}
+
+// expr11 parses (value ("," value)*)?
+func (p *JSONParser) expr11(pos int) (any, int, bool) {
+	if output, end, ok := p.expr12(pos); ok {
+		return output, end, true
+	}
+	if p.err != nil {
+		return nil, pos, false
+	}
+	return nil, pos, true
+}
+
+// expr16 parses "]"
+func (p *JSONParser) expr16(pos int) (any, int, bool) {
+	if strings.HasPrefix(p.input[pos:], "]") {
+		return "]", pos + 1, true
+	}
+	p.fail(pos, "\"]\"")
+	return nil, pos, false
+}
+
+// rule_array parses "[" _ (value ("," value)*)? "]"
+func (p *JSONParser) rule_array(pos int) (any, int, bool) {
+	start := pos
+	v0, pos, ok := p.expr10(pos)
+	if !ok {
+		return nil, start, false
+	}
+	v1, pos, ok := p.rule__(pos)
+	if !ok {
+		return nil, start, false
+	}
+	v2, pos, ok := p.expr11(pos)
+	if !ok {
+		return nil, start, false
+	}
+	v3, pos, ok := p.expr16(pos)
+	if !ok {
+		return nil, start, false
+	}
+	return []any{v0, v1, v2, v3}, pos, true
+}
+
+// expr17 parses "\""
+func (p *JSONParser) expr17(pos int) (any, int, bool) {
+	if strings.HasPrefix(p.input[pos:], "\"") {
+		return "\"", pos + 1, true
+	}
+	p.fail(pos, "\"\\\"\"")
+	return nil, pos, false
+}
+
+// match22 matches "\""
+func (p *JSONParser) match22(pos int) (any, int, bool) {
+	if strings.HasPrefix(p.input[pos:], "\"") {
+		return nil, pos + 1, true
+	}
+	p.fail(pos, "\"\\\"\"")
+	return nil, pos, false
+}
+
+// match21 matches !"\""
+func (p *JSONParser) match21(pos int) (any, int, bool) {
+	n := len(p.nodes)
+	p.silent++
+	_, _, ok := p.match22(pos)
+	p.silent--
+	p.nodes = p.nodes[:n]
+	if ok {
+		p.fail(pos, "not [\"\\\"\"]")
+		return nil, pos, false
+	}
+	return nil, pos, true
+}
+
+// match23 matches .
+func (p *JSONParser) match23(pos int) (any, int, bool) {
+	if _, n := utf8.DecodeRuneInString(p.input[pos:]); n > 0 {
+		return nil, pos + n, true
+	}
+	p.fail(pos, "ANYTHING")
+	return nil, pos, false
+}
+
+// match20 matches !"\"" .
+func (p *JSONParser) match20(pos int) (any, int, bool) {
+	start := pos
+	n := len(p.nodes)
+	var ok bool
+	_, pos, ok = p.match21(pos)
+	if !ok {
+		p.nodes = p.nodes[:n]
+		return nil, start, false
+	}
+	_, pos, ok = p.match23(pos)
+	if !ok {
+		p.nodes = p.nodes[:n]
+		return nil, start, false
+	}
+	return nil, pos, true
+}
+
+// match19 matches (!"\"" .)*
+func (p *JSONParser) match19(pos int) (any, int, bool) {
+	count := 0
+	for {
+		n := len(p.nodes)
+		_, end, ok := p.match20(pos)
+		if !ok || (count > 0 && end == pos) {
+			p.nodes = p.nodes[:n]
+			break
+		}
+		count++
+		pos = end
+	}
+	return nil, pos, true
+}
+
+// expr18 parses $(!"\"" .)*
+func (p *JSONParser) expr18(pos int) (any, int, bool) {
+	if _, end, ok := p.match19(pos); ok {
+		return p.input[pos:end], end, true
+	}
+	return nil, pos, false
+}
+
+// expr24 parses "\""
+func (p *JSONParser) expr24(pos int) (any, int, bool) {
+	if strings.HasPrefix(p.input[pos:], "\"") {
+		return "\"", pos + 1, true
+	}
+	p.fail(pos, "\"\\\"\"")
+	return nil, pos, false
+}
+
+// rule_string parses "\"" $(!"\"" .)* "\""
+func (p *JSONParser) rule_string(pos int) (any, int, bool) {
+	start := pos
+	v0, pos, ok := p.expr17(pos)
+	if !ok {
+		return nil, start, false
+	}
+	v1, pos, ok := p.expr18(pos)
+	if !ok {
+		return nil, start, false
+	}
+	v2, pos, ok := p.expr24(pos)
+	if !ok {
+		return nil, start, false
+	}
+	return []any{v0, v1, v2}, pos, true
+}
+
+// match28 matches "-"
+func (p *JSONParser) match28(pos int) (any, int, bool) {
+	if strings.HasPrefix(p.input[pos:], "-") {
+		return nil, pos + 1, true
+	}
+	p.fail(pos, "\"-\"")
+	return nil, pos, false
+}
+
+// match27 matches "-"?
+func (p *JSONParser) match27(pos int) (any, int, bool) {
+	if _, end, ok := p.match28(pos); ok {
+		return nil, end, true
+	}
+	return nil, pos, true
+}
+
+// match30 matches [0-9]
+func (p *JSONParser) match30(pos int) (any, int, bool) {
+	if c, n := utf8.DecodeRuneInString(p.input[pos:]); n > 0 && '0' <= c && c <= '9' {
+		return nil, pos + n, true
+	}
+	p.fail(pos, "[0-9]")
+	return nil, pos, false
+}
+
+// match29 matches [0-9]+
+func (p *JSONParser) match29(pos int) (any, int, bool) {
+	count := 0
+	for {
+		n := len(p.nodes)
+		_, end, ok := p.match30(pos)
+		if !ok || (count > 0 && end == pos) {
+			p.nodes = p.nodes[:n]
+			break
+		}
+		count++
+		pos = end
+	}
+	if count == 0 {
+		return nil, pos, false
+	}
+	return nil, pos, true
+}
+
+// match33 matches "."
+func (p *JSONParser) match33(pos int) (any, int, bool) {
+	if strings.HasPrefix(p.input[pos:], ".") {
+		return nil, pos + 1, true
+	}
+	p.fail(pos, "\".\"")
+	return nil, pos, false
+}
+
+// match32 matches "." [0-9]+
+func (p *JSONParser) match32(pos int) (any, int, bool) {
+	start := pos
+	n := len(p.nodes)
+	var ok bool
+	_, pos, ok = p.match33(pos)
+	if !ok {
+		p.nodes = p.nodes[:n]
+		return nil, start, false
+	}
+	_, pos, ok = p.match29(pos)
+	if !ok {
+		p.nodes = p.nodes[:n]
+		return nil, start, false
+	}
+	return nil, pos, true
+}
+
+// match31 matches ("." [0-9]+)?
+func (p *JSONParser) match31(pos int) (any, int, bool) {
+	if _, end, ok := p.match32(pos); ok {
+		return nil, end, true
+	}
+	return nil, pos, true
+}
+
+// match26 matches "-"? [0-9]+ ("." [0-9]+)?
+func (p *JSONParser) match26(pos int) (any, int, bool) {
+	start := pos
+	n := len(p.nodes)
+	var ok bool
+	_, pos, ok = p.match27(pos)
+	if !ok {
+		p.nodes = p.nodes[:n]
+		return nil, start, false
+	}
+	_, pos, ok = p.match29(pos)
+	if !ok {
+		p.nodes = p.nodes[:n]
+		return nil, start, false
+	}
+	_, pos, ok = p.match31(pos)
+	if !ok {
+		p.nodes = p.nodes[:n]
+		return nil, start, false
+	}
+	return nil, pos, true
+}
+
+// expr25 parses $("-"? [0-9]+ ("." [0-9]+)?)
+func (p *JSONParser) expr25(pos int) (any, int, bool) {
+	if _, end, ok := p.match26(pos); ok {
+		return p.input[pos:end], end, true
+	}
+	return nil, pos, false
+}
+
+// rule_number parses $("-"? [0-9]+ ("." [0-9]+)?) {number}
+func (p *JSONParser) rule_number(pos int) (any, int, bool) {
+	start := pos
+	v0, pos, ok := p.expr25(pos)
+	if !ok {
+		return nil, start, false
+	}
+	output, err := p.actions[0](v0)
+	if err != nil {
+		if p.err == nil {
+			p.err = err
+		}
+		return nil, pos, false
+	}
+	return output, pos, true
+}
+
+// match35 matches [tfn]
+func (p *JSONParser) match35(pos int) (any, int, bool) {
+	if c, n := utf8.DecodeRuneInString(p.input[pos:]); n > 0 && (c == 't' || c == 'f' || c == 'n') {
+		return nil, pos + n, true
+	}
+	p.fail(pos, "[tfn]")
+	return nil, pos, false
+}
+
+// expr34 parses &[tfn]
+func (p *JSONParser) expr34(pos int) (any, int, bool) {
+	n := len(p.nodes)
+	_, _, ok := p.match35(pos)
+	p.nodes = p.nodes[:n]
+	return nil, pos, ok
+}
+
+// expr37 parses "true"
+func (p *JSONParser) expr37(pos int) (any, int, bool) {
+	if strings.HasPrefix(p.input[pos:], "true") {
+		return "true", pos + 4, true
+	}
+	p.fail(pos, "\"true\"")
+	return nil, pos, false
+}
+
+// expr38 parses "false"
+func (p *JSONParser) expr38(pos int) (any, int, bool) {
+	if strings.HasPrefix(p.input[pos:], "false") {
+		return "false", pos + 5, true
+	}
+	p.fail(pos, "\"false\"")
+	return nil, pos, false
+}
+
+// expr39 parses "null"
+func (p *JSONParser) expr39(pos int) (any, int, bool) {
+	if strings.HasPrefix(p.input[pos:], "null") {
+		return "null", pos + 4, true
+	}
+	p.fail(pos, "\"null\"")
+	return nil, pos, false
+}
+
+// expr36 parses "true" / "false" / "null"
+func (p *JSONParser) expr36(pos int) (any, int, bool) {
+	if output, end, ok := p.expr37(pos); ok {
+		return output, end, true
+	}
+	if p.err != nil {
+		return nil, pos, false
+	}
+	if output, end, ok := p.expr38(pos); ok {
+		return output, end, true
+	}
+	if p.err != nil {
+		return nil, pos, false
+	}
+	if output, end, ok := p.expr39(pos); ok {
+		return output, end, true
+	}
+	if p.err != nil {
+		return nil, pos, false
+	}
+	return nil, pos, false
+}
+
+// match41 matches [a-zA-Z0-9_]
+func (p *JSONParser) match41(pos int) (any, int, bool) {
+	if c, n := utf8.DecodeRuneInString(p.input[pos:]); n > 0 && ('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c == '_') {
+		return nil, pos + n, true
+	}
+	p.fail(pos, "[a-zA-Z0-9_]")
+	return nil, pos, false
+}
+
+// expr40 parses ![a-zA-Z0-9_]
+func (p *JSONParser) expr40(pos int) (any, int, bool) {
+	n := len(p.nodes)
+	p.silent++
+	_, _, ok := p.match41(pos)
+	p.silent--
+	p.nodes = p.nodes[:n]
+	if ok {
+		p.fail(pos, "not [[a-zA-Z0-9_]]")
+		return nil, pos, false
+	}
+	return nil, pos, true
+}
+
+// rule_literal parses &[tfn] ("true" / "false" / "null") ![a-zA-Z0-9_]
+func (p *JSONParser) rule_literal(pos int) (any, int, bool) {
+	start := pos
+	v0, pos, ok := p.expr34(pos)
+	if !ok {
+		return nil, start, false
+	}
+	v1, pos, ok := p.expr36(pos)
+	if !ok {
+		return nil, start, false
+	}
+	v2, pos, ok := p.expr40(pos)
+	if !ok {
+		return nil, start, false
+	}
+	return []any{v0, v1, v2}, pos, true
+}
+
+// expr42 parses [ \t\r\n]
+func (p *JSONParser) expr42(pos int) (any, int, bool) {
+	if c, n := utf8.DecodeRuneInString(p.input[pos:]); n > 0 && (c == ' ' || c == '\t' || c == '\r' || c == '\n') {
+		return p.input[pos : pos+n], pos + n, true
+	}
+	p.fail(pos, "[ \\t\\r\\n]")
+	return nil, pos, false
+}
+
+// rule__ parses [ \t\r\n]*
+func (p *JSONParser) rule__(pos int) (any, int, bool) {
+	outputs := []any{}
+	for {
+		output, end, ok := p.expr42(pos)
+		if !ok || (len(outputs) > 0 && end == pos) {
+			break
+		}
+		outputs = append(outputs, output)
+		pos = end
+	}
+	if p.err != nil {
+		return nil, pos, false
+	}
+	return outputs, pos, true
+}
+
+// match45 matches [ \t\r\n]
+func (p *JSONParser) match45(pos int) (any, int, bool) {
+	if c, n := utf8.DecodeRuneInString(p.input[pos:]); n > 0 && (c == ' ' || c == '\t' || c == '\r' || c == '\n') {
+		return nil, pos + n, true
+	}
+	p.fail(pos, "[ \\t\\r\\n]")
+	return nil, pos, false
+}
+
+// match44 matches [ \t\r\n]*
+func (p *JSONParser) match44(pos int) (any, int, bool) {
+	count := 0
+	for {
+		n := len(p.nodes)
+		_, end, ok := p.match45(pos)
+		if !ok || (count > 0 && end == pos) {
+			p.nodes = p.nodes[:n]
+			break
+		}
+		count++
+		pos = end
+	}
+	return nil, pos, true
+}
+
+// match__ matches the rule _, and records it as a node.
+func (p *JSONParser) match__(pos int) (any, int, bool) {
+	i := len(p.nodes)
+	p.nodes = append(p.nodes, JSONNode{Rule: "_", Start: pos})
+	_, end, ok := p.match44(pos)
+	if !ok {
+		p.nodes = p.nodes[:i]
+		return nil, pos, false
+	}
+	p.nodes[i].End, p.nodes[i].Size = end, len(p.nodes)-i
+	return nil, end, true
+}
+
+// match48 matches "{"
+func (p *JSONParser) match48(pos int) (any, int, bool) {
+	if strings.HasPrefix(p.input[pos:], "{") {
+		return nil, pos + 1, true
+	}
+	p.fail(pos, "\"{\"")
+	return nil, pos, false
+}
+
+// match53 matches $(!"\"" .)*
+func (p *JSONParser) match53(pos int) (any, int, bool) {
+	if _, end, ok := p.match19(pos); ok {
+		return nil, end, true
+	}
+	return nil, pos, false
+}
+
+// match52 matches "\"" $(!"\"" .)* "\""
+func (p *JSONParser) match52(pos int) (any, int, bool) {
+	start := pos
+	n := len(p.nodes)
+	var ok bool
+	_, pos, ok = p.match22(pos)
+	if !ok {
+		p.nodes = p.nodes[:n]
+		return nil, start, false
+	}
+	_, pos, ok = p.match53(pos)
+	if !ok {
+		p.nodes = p.nodes[:n]
+		return nil, start, false
+	}
+	_, pos, ok = p.match22(pos)
+	if !ok {
+		p.nodes = p.nodes[:n]
+		return nil, start, false
+	}
+	return nil, pos, true
+}
+
+// match_string matches the rule string, and records it as a node.
+func (p *JSONParser) match_string(pos int) (any, int, bool) {
+	i := len(p.nodes)
+	p.nodes = append(p.nodes, JSONNode{Rule: "string", Start: pos})
+	_, end, ok := p.match52(pos)
+	if !ok {
+		p.nodes = p.nodes[:i]
+		return nil, pos, false
+	}
+	p.nodes[i].End, p.nodes[i].Size = end, len(p.nodes)-i
+	return nil, end, true
+}
+
+// match54 matches ":"
+func (p *JSONParser) match54(pos int) (any, int, bool) {
+	if strings.HasPrefix(p.input[pos:], ":") {
+		return nil, pos + 1, true
+	}
+	p.fail(pos, "\":\"")
+	return nil, pos, false
+}
+
+// match51 matches string _ ":" value
+func (p *JSONParser) match51(pos int) (any, int, bool) {
+	start := pos
+	n := len(p.nodes)
+	var ok bool
+	_, pos, ok = p.match_string(pos)
+	if !ok {
+		p.nodes = p.nodes[:n]
+		return nil, start, false
+	}
+	_, pos, ok = p.match__(pos)
+	if !ok {
+		p.nodes = p.nodes[:n]
+		return nil, start, false
+	}
+	_, pos, ok = p.match54(pos)
+	if !ok {
+		p.nodes = p.nodes[:n]
+		return nil, start, false
+	}
+	_, pos, ok = p.match_value(pos)
+	if !ok {
+		p.nodes = p.nodes[:n]
+		return nil, start, false
+	}
+	return nil, pos, true
+}
+
+// match_member matches the rule member, and records it as a node.
+func (p *JSONParser) match_member(pos int) (any, int, bool) {
+	i := len(p.nodes)
+	p.nodes = append(p.nodes, JSONNode{Rule: "member", Start: pos})
+	_, end, ok := p.match51(pos)
+	if !ok {
+		p.nodes = p.nodes[:i]
+		return nil, pos, false
+	}
+	p.nodes[i].End, p.nodes[i].Size = end, len(p.nodes)-i
+	return nil, end, true
+}
+
+// match57 matches ","
+func (p *JSONParser) match57(pos int) (any, int, bool) {
+	if strings.HasPrefix(p.input[pos:], ",") {
+		return nil, pos + 1, true
+	}
+	p.fail(pos, "\",\"")
+	return nil, pos, false
+}
+
+// match56 matches "," _ member
+func (p *JSONParser) match56(pos int) (any, int, bool) {
+	start := pos
+	n := len(p.nodes)
+	var ok bool
+	_, pos, ok = p.match57(pos)
+	if !ok {
+		p.nodes = p.nodes[:n]
+		return nil, start, false
+	}
+	_, pos, ok = p.match__(pos)
+	if !ok {
+		p.nodes = p.nodes[:n]
+		return nil, start, false
+	}
+	_, pos, ok = p.match_member(pos)
+	if !ok {
+		p.nodes = p.nodes[:n]
+		return nil, start, false
+	}
+	return nil, pos, true
+}
+
+// match55 matches ("," _ member)*
+func (p *JSONParser) match55(pos int) (any, int, bool) {
+	count := 0
+	for {
+		n := len(p.nodes)
+		_, end, ok := p.match56(pos)
+		if !ok || (count > 0 && end == pos) {
+			p.nodes = p.nodes[:n]
+			break
+		}
+		count++
+		pos = end
+	}
+	return nil, pos, true
+}
+
+// match50 matches member ("," _ member)*
+func (p *JSONParser) match50(pos int) (any, int, bool) {
+	start := pos
+	n := len(p.nodes)
+	var ok bool
+	_, pos, ok = p.match_member(pos)
+	if !ok {
+		p.nodes = p.nodes[:n]
+		return nil, start, false
+	}
+	_, pos, ok = p.match55(pos)
+	if !ok {
+		p.nodes = p.nodes[:n]
+		return nil, start, false
+	}
+	return nil, pos, true
+}
+
+// match49 matches (member ("," _ member)*)?
+func (p *JSONParser) match49(pos int) (any, int, bool) {
+	if _, end, ok := p.match50(pos); ok {
+		return nil, end, true
+	}
+	return nil, pos, true
+}
+
+// match58 matches "}"
+func (p *JSONParser) match58(pos int) (any, int, bool) {
+	if strings.HasPrefix(p.input[pos:], "}") {
+		return nil, pos + 1, true
+	}
+	p.fail(pos, "\"}\"")
+	return nil, pos, false
+}
+
+// match47 matches "{" _ (member ("," _ member)*)? "}"
+func (p *JSONParser) match47(pos int) (any, int, bool) {
+	start := pos
+	n := len(p.nodes)
+	var ok bool
+	_, pos, ok = p.match48(pos)
+	if !ok {
+		p.nodes = p.nodes[:n]
+		return nil, start, false
+	}
+	_, pos, ok = p.match__(pos)
+	if !ok {
+		p.nodes = p.nodes[:n]
+		return nil, start, false
+	}
+	_, pos, ok = p.match49(pos)
+	if !ok {
+		p.nodes = p.nodes[:n]
+		return nil, start, false
+	}
+	_, pos, ok = p.match58(pos)
+	if !ok {
+		p.nodes = p.nodes[:n]
+		return nil, start, false
+	}
+	return nil, pos, true
+}
+
+// match_object matches the rule object, and records it as a node.
+func (p *JSONParser) match_object(pos int) (any, int, bool) {
+	i := len(p.nodes)
+	p.nodes = append(p.nodes, JSONNode{Rule: "object", Start: pos})
+	_, end, ok := p.match47(pos)
+	if !ok {
+		p.nodes = p.nodes[:i]
+		return nil, pos, false
+	}
+	p.nodes[i].End, p.nodes[i].Size = end, len(p.nodes)-i
+	return nil, end, true
+}
+
+// match60 matches "["
+func (p *JSONParser) match60(pos int) (any, int, bool) {
+	if strings.HasPrefix(p.input[pos:], "[") {
+		return nil, pos + 1, true
+	}
+	p.fail(pos, "\"[\"")
+	return nil, pos, false
+}
+
+// match64 matches "," value
+func (p *JSONParser) match64(pos int) (any, int, bool) {
+	start := pos
+	n := len(p.nodes)
+	var ok bool
+	_, pos, ok = p.match57(pos)
+	if !ok {
+		p.nodes = p.nodes[:n]
+		return nil, start, false
+	}
+	_, pos, ok = p.match_value(pos)
+	if !ok {
+		p.nodes = p.nodes[:n]
+		return nil, start, false
+	}
+	return nil, pos, true
+}
+
+// match63 matches ("," value)*
+func (p *JSONParser) match63(pos int) (any, int, bool) {
+	count := 0
+	for {
+		n := len(p.nodes)
+		_, end, ok := p.match64(pos)
+		if !ok || (count > 0 && end == pos) {
+			p.nodes = p.nodes[:n]
+			break
+		}
+		count++
+		pos = end
+	}
+	return nil, pos, true
+}
+
+// match62 matches value ("," value)*
+func (p *JSONParser) match62(pos int) (any, int, bool) {
+	start := pos
+	n := len(p.nodes)
+	var ok bool
+	_, pos, ok = p.match_value(pos)
+	if !ok {
+		p.nodes = p.nodes[:n]
+		return nil, start, false
+	}
+	_, pos, ok = p.match63(pos)
+	if !ok {
+		p.nodes = p.nodes[:n]
+		return nil, start, false
+	}
+	return nil, pos, true
+}
+
+// match61 matches (value ("," value)*)?
+func (p *JSONParser) match61(pos int) (any, int, bool) {
+	if _, end, ok := p.match62(pos); ok {
+		return nil, end, true
+	}
+	return nil, pos, true
+}
+
+// match65 matches "]"
+func (p *JSONParser) match65(pos int) (any, int, bool) {
+	if strings.HasPrefix(p.input[pos:], "]") {
+		return nil, pos + 1, true
+	}
+	p.fail(pos, "\"]\"")
+	return nil, pos, false
+}
+
+// match59 matches "[" _ (value ("," value)*)? "]"
+func (p *JSONParser) match59(pos int) (any, int, bool) {
+	start := pos
+	n := len(p.nodes)
+	var ok bool
+	_, pos, ok = p.match60(pos)
+	if !ok {
+		p.nodes = p.nodes[:n]
+		return nil, start, false
+	}
+	_, pos, ok = p.match__(pos)
+	if !ok {
+		p.nodes = p.nodes[:n]
+		return nil, start, false
+	}
+	_, pos, ok = p.match61(pos)
+	if !ok {
+		p.nodes = p.nodes[:n]
+		return nil, start, false
+	}
+	_, pos, ok = p.match65(pos)
+	if !ok {
+		p.nodes = p.nodes[:n]
+		return nil, start, false
+	}
+	return nil, pos, true
+}
+
+// match_array matches the rule array, and records it as a node.
+func (p *JSONParser) match_array(pos int) (any, int, bool) {
+	i := len(p.nodes)
+	p.nodes = append(p.nodes, JSONNode{Rule: "array", Start: pos})
+	_, end, ok := p.match59(pos)
+	if !ok {
+		p.nodes = p.nodes[:i]
+		return nil, pos, false
+	}
+	p.nodes[i].End, p.nodes[i].Size = end, len(p.nodes)-i
+	return nil, end, true
+}
+
+// match67 matches $("-"? [0-9]+ ("." [0-9]+)?)
+func (p *JSONParser) match67(pos int) (any, int, bool) {
+	if _, end, ok := p.match26(pos); ok {
+		return nil, end, true
+	}
+	return nil, pos, false
+}
+
+// match66 matches $("-"? [0-9]+ ("." [0-9]+)?) {number}
+func (p *JSONParser) match66(pos int) (any, int, bool) {
+	start := pos
+	n := len(p.nodes)
+	var ok bool
+	_, pos, ok = p.match67(pos)
+	if !ok {
+		p.nodes = p.nodes[:n]
+		return nil, start, false
+	}
+	return nil, pos, true
+}
+
+// match_number matches the rule number, and records it as a node.
+func (p *JSONParser) match_number(pos int) (any, int, bool) {
+	i := len(p.nodes)
+	p.nodes = append(p.nodes, JSONNode{Rule: "number", Start: pos})
+	_, end, ok := p.match66(pos)
+	if !ok {
+		p.nodes = p.nodes[:i]
+		return nil, pos, false
+	}
+	p.nodes[i].End, p.nodes[i].Size = end, len(p.nodes)-i
+	return nil, end, true
+}
+
+// match69 matches &[tfn]
+func (p *JSONParser) match69(pos int) (any, int, bool) {
+	n := len(p.nodes)
+	_, _, ok := p.match35(pos)
+	p.nodes = p.nodes[:n]
+	return nil, pos, ok
+}
+
+// match71 matches "true"
+func (p *JSONParser) match71(pos int) (any, int, bool) {
+	if strings.HasPrefix(p.input[pos:], "true") {
+		return nil, pos + 4, true
+	}
+	p.fail(pos, "\"true\"")
+	return nil, pos, false
+}
+
+// match72 matches "false"
+func (p *JSONParser) match72(pos int) (any, int, bool) {
+	if strings.HasPrefix(p.input[pos:], "false") {
+		return nil, pos + 5, true
+	}
+	p.fail(pos, "\"false\"")
+	return nil, pos, false
+}
+
+// match73 matches "null"
+func (p *JSONParser) match73(pos int) (any, int, bool) {
+	if strings.HasPrefix(p.input[pos:], "null") {
+		return nil, pos + 4, true
+	}
+	p.fail(pos, "\"null\"")
+	return nil, pos, false
+}
+
+// match70 matches "true" / "false" / "null"
+func (p *JSONParser) match70(pos int) (any, int, bool) {
+	if output, end, ok := p.match71(pos); ok {
+		return output, end, true
+	}
+	if output, end, ok := p.match72(pos); ok {
+		return output, end, true
+	}
+	if output, end, ok := p.match73(pos); ok {
+		return output, end, true
+	}
+	return nil, pos, false
+}
+
+// match74 matches ![a-zA-Z0-9_]
+func (p *JSONParser) match74(pos int) (any, int, bool) {
+	n := len(p.nodes)
+	p.silent++
+	_, _, ok := p.match41(pos)
+	p.silent--
+	p.nodes = p.nodes[:n]
+	if ok {
+		p.fail(pos, "not [[a-zA-Z0-9_]]")
+		return nil, pos, false
+	}
+	return nil, pos, true
+}
+
+// match68 matches &[tfn] ("true" / "false" / "null") ![a-zA-Z0-9_]
+func (p *JSONParser) match68(pos int) (any, int, bool) {
+	start := pos
+	n := len(p.nodes)
+	var ok bool
+	_, pos, ok = p.match69(pos)
+	if !ok {
+		p.nodes = p.nodes[:n]
+		return nil, start, false
+	}
+	_, pos, ok = p.match70(pos)
+	if !ok {
+		p.nodes = p.nodes[:n]
+		return nil, start, false
+	}
+	_, pos, ok = p.match74(pos)
+	if !ok {
+		p.nodes = p.nodes[:n]
+		return nil, start, false
+	}
+	return nil, pos, true
+}
+
+// match_literal matches the rule literal, and records it as a node.
+func (p *JSONParser) match_literal(pos int) (any, int, bool) {
+	i := len(p.nodes)
+	p.nodes = append(p.nodes, JSONNode{Rule: "literal", Start: pos})
+	_, end, ok := p.match68(pos)
+	if !ok {
+		p.nodes = p.nodes[:i]
+		return nil, pos, false
+	}
+	p.nodes[i].End, p.nodes[i].Size = end, len(p.nodes)-i
+	return nil, end, true
+}
+
+// match46 matches object / array / string / number / literal
+func (p *JSONParser) match46(pos int) (any, int, bool) {
+	if output, end, ok := p.match_object(pos); ok {
+		return output, end, true
+	}
+	if output, end, ok := p.match_array(pos); ok {
+		return output, end, true
+	}
+	if output, end, ok := p.match_string(pos); ok {
+		return output, end, true
+	}
+	if output, end, ok := p.match_number(pos); ok {
+		return output, end, true
+	}
+	if output, end, ok := p.match_literal(pos); ok {
+		return output, end, true
+	}
+	return nil, pos, false
+}
+
+// match43 matches _ (object / array / string / number / literal) _
+func (p *JSONParser) match43(pos int) (any, int, bool) {
+	start := pos
+	n := len(p.nodes)
+	var ok bool
+	_, pos, ok = p.match__(pos)
+	if !ok {
+		p.nodes = p.nodes[:n]
+		return nil, start, false
+	}
+	_, pos, ok = p.match46(pos)
+	if !ok {
+		p.nodes = p.nodes[:n]
+		return nil, start, false
+	}
+	_, pos, ok = p.match__(pos)
+	if !ok {
+		p.nodes = p.nodes[:n]
+		return nil, start, false
+	}
+	return nil, pos, true
+}
+
+// match_value matches the rule value, and records it as a node.
+func (p *JSONParser) match_value(pos int) (any, int, bool) {
+	i := len(p.nodes)
+	p.nodes = append(p.nodes, JSONNode{Rule: "value", Start: pos})
+	_, end, ok := p.match43(pos)
+	if !ok {
+		p.nodes = p.nodes[:i]
+		return nil, pos, false
+	}
+	p.nodes[i].End, p.nodes[i].Size = end, len(p.nodes)-i
+	return nil, end, true
+}
@@ -0,0 +1,319 @@
+// Code generated by parcon-gen. DO NOT EDIT.
+
+package gentest
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// ParseList parses the whole of input, and returns the output of the rule list.
+// The actions are called by the names in the grammar.
+func ParseList(input string, actions map[string]func(any) (any, error)) (any, error) {
+	p := ListParser{input: input}
+	for i, name := range [1]string{"list"} {
+		fn, ok := actions[name]
+		if !ok {
+			return nil, fmt.Errorf("undefined action %q", name)
+		}
+		p.actions[i] = fn
+	}
+
+	output, pos, ok := p.rule_list(0)
+	if p.err != nil {
+		return nil, p.err
+	}
+	if ok && pos == len(input) {
+		return output, nil
+	}
+	if ok {
+		p.fail(pos, "EOF")
+	}
+	return nil, p.error()
+}
+
+// ListParser parses input into the syntax tree of rules, without allocation once its buffers grown enough.
+// The zero value is ready to use, and it can be reused to parse other inputs, but not from multiple goroutines at the same time.
+type ListParser struct {
+	input   string
+	actions [1]func(any) (any, error)
+	err     error
+
+	// silent is greater than 0 while parsing in a negative lookahead, that does not report errors.
+	silent int
+
+	// failPos is the furthest position that failed, and expected is things expected at there.
+	failPos  int
+	expected []string
+
+	// nodes is the syntax tree that made while matching.
+	nodes []ListNode
+}
+
+// ListNode is a node of the syntax tree that made by ListParser.
+// It is the span of a rule in the input.
+type ListNode struct {
+	Rule       string
+	Start, End int
+
+	// Size is the number of nodes in the subtree including this node.
+	// The children follow this node, and the next sibling is at the index plus Size.
+	Size int
+}
+
+// Parse parses the whole of input, and returns the syntax tree of rules in pre-order.
+// It does not call actions.
+// The nodes are valid until the next call of Parse.
+func (p *ListParser) Parse(input string) ([]ListNode, error) {
+	p.input, p.err, p.silent, p.failPos = input, nil, 0, 0
+	p.expected, p.nodes = p.expected[:0], p.nodes[:0]
+
+	_, pos, ok := p.match_list(0)
+	if ok && pos == len(input) {
+		return p.nodes, nil
+	}
+	if ok {
+		p.fail(pos, "EOF")
+	}
+	return nil, p.error()
+}
+
+// fail records that expected is not found at pos.
+func (p *ListParser) fail(pos int, expected string) {
+	if p.silent > 0 || pos < p.failPos {
+		return
+	}
+	if pos > p.failPos {
+		p.failPos = pos
+		p.expected = p.expected[:0]
+	}
+	for _, e := range p.expected {
+		if e == expected {
+			return
+		}
+	}
+	p.expected = append(p.expected, expected)
+}
+
+// error makes an error at the furthest position that failed.
+func (p *ListParser) error() error {
+	line, column := 1, 1
+	for _, c := range p.input[:p.failPos] {
+		if c == '\n' {
+			line++
+			column = 1
+		} else {
+			column++
+		}
+	}
+
+	got := "end of input"
+	if p.failPos < len(p.input) {
+		c, _ := utf8.DecodeRuneInString(p.input[p.failPos:])
+		got = fmt.Sprintf("%q", string(c))
+	}
+
+	var expected string
+	switch n := len(p.expected); n {
+	case 0:
+		expected = "nothing"
+	case 1:
+		expected = p.expected[0]
+	case 2:
+		expected = p.expected[0] + " or " + p.expected[1]
+	default:
+		expected = strings.Join(p.expected[:n-1], ", ") + ", or " + p.expected[n-1]
+	}
+
+	return fmt.Errorf("invalid input at %d:%d: expected %s but got %s", line, column, expected, got)
+}
+
+// expr3 parses ","
+func (p *ListParser) expr3(pos int) (any, int, bool) {
+	if strings.HasPrefix(p.input[pos:], ",") {
+		return ",", pos + 1, true
+	}
+	p.fail(pos, "\",\"")
+	return nil, pos, false
+}
+
+// expr2 parses "," item
+func (p *ListParser) expr2(pos int) (any, int, bool) {
+	start := pos
+	v0, pos, ok := p.expr3(pos)
+	if !ok {
+		return nil, start, false
+	}
+	v1, pos, ok := p.rule_item(pos)
+	if !ok {
+		return nil, start, false
+	}
+	return []any{v0, v1}, pos, true
+}
+
+// expr1 parses ("," item)*
+func (p *ListParser) expr1(pos int) (any, int, bool) {
+	outputs := []any{}
+	for {
+		output, end, ok := p.expr2(pos)
+		if !ok || (len(outputs) > 0 && end == pos) {
+			break
+		}
+		outputs = append(outputs, output)
+		pos = end
+	}
+	if p.err != nil {
+		return nil, pos, false
+	}
+	return outputs, pos, true
+}
+
+// rule_list parses item ("," item)* {list}
+func (p *ListParser) rule_list(pos int) (any, int, bool) {
+	start := pos
+	v0, pos, ok := p.rule_item(pos)
+	if !ok {
+		return nil, start, false
+	}
+	v1, pos, ok := p.expr1(pos)
+	if !ok {
+		return nil, start, false
+	}
+	output, err := p.actions[0]([]any{v0, v1})
+	if err != nil {
+		if p.err == nil {
+			p.err = err
+		}
+		return nil, pos, false
+	}
+	return output, pos, true
+}
+
+// match5 matches [^,]
+func (p *ListParser) match5(pos int) (any, int, bool) {
+	if c, n := utf8.DecodeRuneInString(p.input[pos:]); n > 0 && !(c == ',') {
+		return nil, pos + n, true
+	}
+	p.fail(pos, "[^,]")
+	return nil, pos, false
+}
+
+// match4 matches [^,]*
+func (p *ListParser) match4(pos int) (any, int, bool) {
+	count := 0
+	for {
+		n := len(p.nodes)
+		_, end, ok := p.match5(pos)
+		if !ok || (count > 0 && end == pos) {
+			p.nodes = p.nodes[:n]
+			break
+		}
+		count++
+		pos = end
+	}
+	return nil, pos, true
+}
+
+// rule_item parses $[^,]*
+func (p *ListParser) rule_item(pos int) (any, int, bool) {
+	if _, end, ok := p.match4(pos); ok {
+		return p.input[pos:end], end, true
+	}
+	return nil, pos, false
+}
+
+// match7 matches $[^,]*
+func (p *ListParser) match7(pos int) (any, int, bool) {
+	if _, end, ok := p.match4(pos); ok {
+		return nil, end, true
+	}
+	return nil, pos, false
+}
+
+// match_item matches the rule item, and records it as a node.
+func (p *ListParser) match_item(pos int) (any, int, bool) {
+	i := len(p.nodes)
+	p.nodes = append(p.nodes, ListNode{Rule: "item", Start: pos})
+	_, end, ok := p.match7(pos)
+	if !ok {
+		p.nodes = p.nodes[:i]
+		return nil, pos, false
+	}
+	p.nodes[i].End, p.nodes[i].Size = end, len(p.nodes)-i
+	return nil, end, true
+}
+
+// match10 matches ","
+func (p *ListParser) match10(pos int) (any, int, bool) {
+	if strings.HasPrefix(p.input[pos:], ",") {
+		return nil, pos + 1, true
+	}
+	p.fail(pos, "\",\"")
+	return nil, pos, false
+}
+
+// match9 matches "," item
+func (p *ListParser) match9(pos int) (any, int, bool) {
+	start := pos
+	n := len(p.nodes)
+	var ok bool
+	_, pos, ok = p.match10(pos)
+	if !ok {
+		p.nodes = p.nodes[:n]
+		return nil, start, false
+	}
+	_, pos, ok = p.match_item(pos)
+	if !ok {
+		p.nodes = p.nodes[:n]
+		return nil, start, false
+	}
+	return nil, pos, true
+}
+
+// match8 matches ("," item)*
+func (p *ListParser) match8(pos int) (any, int, bool) {
+	count := 0
+	for {
+		n := len(p.nodes)
+		_, end, ok := p.match9(pos)
+		if !ok || (count > 0 && end == pos) {
+			p.nodes = p.nodes[:n]
+			break
+		}
+		count++
+		pos = end
+	}
+	return nil, pos, true
+}
+
+// match6 matches item ("," item)* {list}
+func (p *ListParser) match6(pos int) (any, int, bool) {
+	start := pos
+	n := len(p.nodes)
+	var ok bool
+	_, pos, ok = p.match_item(pos)
+	if !ok {
+		p.nodes = p.nodes[:n]
+		return nil, start, false
+	}
+	_, pos, ok = p.match8(pos)
+	if !ok {
+		p.nodes = p.nodes[:n]
+		return nil, start, false
+	}
+	return nil, pos, true
+}
+
+// match_list matches the rule list, and records it as a node.
+func (p *ListParser) match_list(pos int) (any, int, bool) {
+	i := len(p.nodes)
+	p.nodes = append(p.nodes, ListNode{Rule: "list", Start: pos})
+	_, end, ok := p.match6(pos)
+	if !ok {
+		p.nodes = p.nodes[:i]
+		return nil, pos, false
+	}
+	p.nodes[i].End, p.nodes[i].Size = end, len(p.nodes)-i
+	return nil, end, true
+}
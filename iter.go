@@ -0,0 +1,94 @@
+package parcon
+
+// ManyIter returns an iterator that parses values from the whole of `input` one by one using the given `parser`.
+// It is similar to Many, but it does not make a slice of values.
+//
+// The iterator calls `yield` for each value with its Span, until `yield` returns false or all of the input is parsed.
+// If failed to parse, the iterator calls `yield` with the error and stops.
+//
+// If some errors are recovered by Recover while parsing a value, the iterator calls `yield` with the value and the error, and continues.
+// The error is an ErrorList if two or more errors are recovered for the same value.
+//
+// The iterator works with range-over-func in Go 1.23 or later, like below.
+//
+//	for x, err := range parcon.ManyIter(parser, input) {
+//		...
+//	}
+//
+// The input is parsed in a new Session that made with `options`.
+func ManyIter[I comparable, O any](parser Parser[I, O], input []I, options ...Option) func(yield func(Spanned[O], error) bool) {
+	return SeparatedIter[I, O, struct{}](Nothing[I](), parser, input, options...)
+}
+
+// SeparatedIter returns an iterator that parses values separated by `delimiter` from the whole of `input` one by one using the given `parser`.
+// It is similar to SeparatedList, but it does not make a slice of values.
+//
+// Please see also ManyIter.
+func SeparatedIter[I comparable, O, D any](delimiter Parser[I, D], parser Parser[I, O], input []I, options ...Option) func(yield func(Spanned[O], error) bool) {
+	return func(yield func(Spanned[O], error) bool) {
+		s := NewSession(input, options...)
+		defer s.Close()
+
+		// recovered returns errors that recovered by Recover since the last call, and `err` if it is not nil.
+		reported := 0
+		recovered := func(err error) error {
+			errs := s.errors[reported:]
+			reported = len(s.errors)
+
+			switch {
+			case len(errs) == 0:
+				return err
+			case len(errs) == 1 && err == nil:
+				return errs[0]
+			case err == nil:
+				return append(ErrorList(nil), errs...)
+			default:
+				return append(append(ErrorList(nil), errs...), err)
+			}
+		}
+
+		remain := s.Input()
+		for i := 0; len(remain) > 0; i++ {
+			var err error
+
+			if i > 0 {
+				cp, start := s.checkpoint(), remain
+				_, remain, err = delimiter.Parse(remain, s.config.verbose)
+				if err != nil {
+					if s.config.verbose {
+						err = s.explain(err, cp, func() {
+							delimiter.Parse(start, true)
+						})
+					}
+					yield(Spanned[O]{}, recovered(err))
+					return
+				}
+			}
+
+			var x Spanned[O]
+			var r []I
+			cp := s.checkpoint()
+			x.Value, r, err = parser.Parse(remain, s.config.verbose)
+			if err == nil && len(r) == len(remain) {
+				// Iterator can not go ahead if the parser consumed nothing.
+				err = newError(parser, remain, s.config.verbose)
+			}
+			if err != nil {
+				if s.config.verbose {
+					err = s.explain(err, cp, func() {
+						parser.Parse(remain, true)
+					})
+				}
+				yield(Spanned[O]{}, recovered(err))
+				return
+			}
+
+			x.Span = Span{s.Position(remain), s.Position(r)}
+			remain = r
+
+			if !yield(x, recovered(nil)) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package parcon_test
+
+import (
+	"fmt"
+
+	"github.com/macrat/parcon"
+)
+
+func ExampleManyIter() {
+	line := parcon.WithSuffix(
+		parcon.Convert(parcon.NoneOfList("LINE", []rune("\n")), parcon.ToString),
+		parcon.SingleNewline,
+	)
+
+	iter := parcon.ManyIter(line, []rune("hello\nworld\nfoo\nbar\n"))
+
+	iter(func(x parcon.Spanned[string], err error) bool {
+		if err != nil {
+			fmt.Println("error:", err)
+			return false
+		}
+		fmt.Printf("%v: %s\n", x.Span.Start, x.Value)
+		return x.Value != "foo"
+	})
+
+	// OUTPUT:
+	// 1:1: hello
+	// 2:1: world
+	// 3:1: foo
+}
+
+func ExampleSeparatedIter() {
+	iter := parcon.SeparatedIter(
+		parcon.TagStr("COMMA", ","),
+		parcon.Convert(parcon.MultiDigits, parcon.ToInt),
+		[]rune("1,2,3,foo"),
+	)
+
+	sum := 0
+	iter(func(x parcon.Spanned[int], err error) bool {
+		if err != nil {
+			fmt.Println("error:", err)
+			return false
+		}
+		sum += x.Value
+		fmt.Printf("%d (sum=%d)\n", x.Value, sum)
+		return true
+	})
+
+	// OUTPUT:
+	// 1 (sum=1)
+	// 2 (sum=3)
+	// 3 (sum=6)
+	// error: invalid input at 1:7: expected DIGIT but got "f"
+}
+
+func ExampleSeparatedIter_recover() {
+	comma := parcon.TagStr("COMMA", ",")
+	end := parcon.Peek(parcon.Or(comma, parcon.Replace(parcon.EOF[rune](), "")))
+	number := parcon.Recover(parcon.WithSuffix(parcon.Convert(parcon.MultiDigits, parcon.ToInt), end), end, -1)
+
+	iter := parcon.SeparatedIter(comma, number, []rune("1,2x,3"))
+
+	iter(func(x parcon.Spanned[int], err error) bool {
+		if err != nil {
+			fmt.Printf("%d at %v: recovered error: %v\n", x.Value, x.Span, err)
+		} else {
+			fmt.Printf("%d at %v\n", x.Value, x.Span)
+		}
+		return true
+	})
+
+	// OUTPUT:
+	// 1 at 1:1-1:2
+	// -1 at 1:3-1:5: recovered error: invalid input at 1:4: expected COMMA or EOF but got "x"
+	// 3 at 1:6-1:7
+}
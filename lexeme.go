@@ -0,0 +1,107 @@
+package parcon
+
+import (
+	"strconv"
+	"unicode"
+)
+
+// Lexeme parses input using `parser`, and skips the spaces after it using `space`.
+//
+// If all tokens of a grammar are made with Lexeme, the grammar only needs to skip spaces at the beginning of the input, like below.
+//
+//	space := parcon.SpaceConsumer(parcon.MultiSpacesOrNewlines, parcon.LineComment("//"))
+//	number := parcon.Lexeme(space, parcon.Convert(parcon.MultiDigits, parcon.ToInt))
+//	comma := parcon.Lexeme(space, parcon.TagStr("COMMA", ","))
+//	parser := parcon.WithPrefix(space, parcon.SeparatedList(0, comma, number))
+//
+// Lexemes is useful to make many tokens that use the same `space`.
+func Lexeme[I comparable, O, S any](space Parser[I, S], parser Parser[I, O]) Parser[I, O] {
+	return WithSuffix(parser, space)
+}
+
+// SpaceConsumer makes a parser that skips spaces and comments.
+//
+// It parses zero or more `space` or `comments`, so it never fails unless a comment is broken, like an unterminated block comment.
+// LineComment, BlockComment, and NestedBlockComment are useful to make `comments`.
+func SpaceConsumer(space Parser[rune, []rune], comments ...Parser[rune, []rune]) Parser[rune, []rune] {
+	return Named("SPACE", MatchOnly(Many(0, Or(append([]Parser[rune, []rune]{space}, comments...)...))))
+}
+
+// LineComment parses a comment that starts with `prefix` and continues until the end of the line, like "//" or "#".
+// The newline is not a part of the comment.
+func LineComment(prefix string) Parser[rune, []rune] {
+	return MatchOnly(
+		Tag(strconv.Quote(prefix), []rune(prefix)),
+		Optional(NoneOfList("CHARACTER", []rune("\r\n"))),
+	)
+}
+
+// BlockComment parses a comment that starts with `start` and ends with `end`, like "/*" and "*/".
+// The comment can not be nested. Please use NestedBlockComment if you want.
+//
+// It fails with ErrCommitted if the comment is not terminated.
+func BlockComment(start, end string) Parser[rune, []rune] {
+	endTag := Tag(strconv.Quote(end), []rune(end))
+
+	return MatchOnly(
+		Tag(strconv.Quote(start), []rune(start)),
+		Commit(MatchOnly(
+			Many(0, WithPrefix(Not(endTag), Anything[rune]())),
+			endTag,
+		)),
+	)
+}
+
+// NestedBlockComment parses a comment that starts with `start` and ends with `end`, like "/*" and "*/".
+// The comment can include other comments, like "/* outer /* inner */ outer */".
+//
+// It fails with ErrCommitted if the comment is not terminated.
+func NestedBlockComment(start, end string) Parser[rune, []rune] {
+	endTag := Tag(strconv.Quote(end), []rune(end))
+
+	var comment Ref[rune, []rune]
+	comment.Set(Named("NESTED_COMMENT", MatchOnly(
+		Tag(strconv.Quote(start), []rune(start)),
+		Commit(MatchOnly(
+			MatchOnly(Many(0, Or(
+				Parser[rune, []rune](&comment),
+				MatchOnly(WithPrefix(Not(endTag), Anything[rune]())),
+			))),
+			endTag,
+		)),
+	)))
+	return &comment
+}
+
+// Lexemes makes tokens that skip the spaces after them using the same Space, as the same as Lexeme.
+// It helps to declare the policy of spaces of a grammar once, like below.
+//
+//	lex := parcon.Lexemes{Space: parcon.SpaceConsumer(parcon.MultiSpacesOrNewlines, parcon.LineComment("#"))}
+//	equal := lex.TagStr("EQUAL", "=")
+//	let := lex.Keyword("LET", "let")
+//
+// Please use Lexeme for other parsers, like `parcon.Lexeme(lex.Space, number)`.
+type Lexemes struct {
+	// Space is the parser for spaces and comments after tokens, like SpaceConsumer.
+	Space Parser[rune, []rune]
+}
+
+// Tag makes a Tag that skips the spaces after it.
+func (l Lexemes) Tag(name string, tag []rune) Parser[rune, []rune] {
+	return Lexeme(l.Space, Tag(name, tag))
+}
+
+// TagStr makes a TagStr that skips the spaces after it.
+func (l Lexemes) TagStr(name, tag string) Parser[rune, string] {
+	return Lexeme(l.Space, TagStr(name, tag))
+}
+
+// Keyword makes a TagStr that is not followed by a letter, a digit, or "_", and skips the spaces after it.
+// For example, the keyword "if" does not match to the beginning of "iffy".
+func (l Lexemes) Keyword(name, word string) Parser[rune, string] {
+	return Lexeme(l.Space, WithSuffix(TagStr(name, word), Not(TakeSingle("IDENTIFIER", isIdentifierChar))))
+}
+
+func isIdentifierChar(c rune) bool {
+	return c == '_' || unicode.IsLetter(c) || unicode.IsDigit(c)
+}
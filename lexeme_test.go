@@ -0,0 +1,50 @@
+package parcon_test
+
+import (
+	"fmt"
+
+	"github.com/macrat/parcon"
+)
+
+func ExampleLexeme() {
+	space := parcon.SpaceConsumer(parcon.MultiSpacesOrNewlines, parcon.LineComment("//"), parcon.BlockComment("/*", "*/"))
+	number := parcon.Lexeme(space, parcon.Convert(parcon.MultiDigits, parcon.ToInt))
+	comma := parcon.Lexeme(space, parcon.TagStr("COMMA", ","))
+
+	// Skip spaces at the beginning, because Lexeme only skips spaces after tokens.
+	parser := parcon.WithPrefix(space, parcon.SeparatedList(0, comma, number))
+
+	fmt.Println(parcon.ParseString(parser, " 1, /* two */ 2, // three\n 3 "))
+	fmt.Println(parcon.ParseString(parser, "1, /* two 2", parcon.Verbose(true)))
+
+	// OUTPUT:
+	// [1 2 3] <nil>
+	// [] invalid input at 1:12: expected ANYTHING or "*/" but got end of input
+}
+
+func ExampleNestedBlockComment() {
+	comment := parcon.NestedBlockComment("/*", "*/")
+
+	fmt.Println(parcon.ParseString(parcon.Convert(comment, parcon.ToString), "/* outer /* inner */ outer */"))
+	_, err := parcon.ParseString(comment, "/* outer /* inner */ outer", parcon.Verbose(true))
+	fmt.Println(err)
+
+	// OUTPUT:
+	// /* outer /* inner */ outer */ <nil>
+	// invalid input at 1:27: expected NESTED_COMMENT, ANYTHING, or "*/" but got end of input
+}
+
+func ExampleLexemes() {
+	lex := parcon.Lexemes{
+		Space: parcon.SpaceConsumer(parcon.MultiSpacesOrNewlines, parcon.LineComment("#")),
+	}
+
+	name := parcon.Lexeme(lex.Space, parcon.Convert(parcon.MultiAlphas, parcon.ToString))
+	let := parcon.WithEnclosure(lex.Keyword("LET", "let"), name, lex.TagStr("SEMICOLON", ";"))
+	parser := parcon.WithPrefix(lex.Space, parcon.Many(0, parcon.Or(let, name)))
+
+	fmt.Println(parcon.ParseString(parser, "# declare\nlet x; # comment\nletter let y;"))
+
+	// OUTPUT:
+	// [x letter y] <nil>
+}
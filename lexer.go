@@ -0,0 +1,183 @@
+package parcon
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Token is a piece of input that made by Lexer.
+type Token struct {
+	// Kind is the kind of the token, that is the name of the LexRule.
+	Kind string
+
+	// Text is the text of the token.
+	Text string
+
+	// Span is the range of the token in the input of Lexer.
+	Span Span
+}
+
+// String returns human readable string like `NUMBER "123"`.
+func (t Token) String() string {
+	return fmt.Sprintf("%s %q", t.Kind, t.Text)
+}
+
+// tokenPosition returns the position of `offset` in the `tokens`.
+// The position is the start of the token in the input of Lexer, or the end of the last token if `offset` is the end.
+func tokenPosition(tokens []Token, offset int) Position {
+	switch {
+	case offset < len(tokens):
+		return tokens[offset].Span.Start
+	case len(tokens) > 0:
+		return tokens[len(tokens)-1].Span.End
+	default:
+		return Position{}
+	}
+}
+
+// LexRule is a rule of Lexer.
+// Please use TokenRule or SkipRule to make it.
+type LexRule struct {
+	kind   string
+	parser Parser[rune, []rune]
+	skip   bool
+}
+
+// TokenRule makes a LexRule that makes a token of the `kind` from the input that matches to `parser`.
+//
+// Regexp is useful to write rules, like below.
+//
+//	parcon.TokenRule("NUMBER", parcon.Regexp("NUMBER", `[0-9]+`))
+func TokenRule[O any](kind string, parser Parser[rune, O]) LexRule {
+	return LexRule{kind, MatchOnly(parser), false}
+}
+
+// SkipRule makes a LexRule that skips the input that matches to `parser`, like spaces or comments.
+func SkipRule[O any](kind string, parser Parser[rune, O]) LexRule {
+	return LexRule{kind, MatchOnly(parser), true}
+}
+
+// Lexer splits input into tokens using rules.
+//
+// Parsers for []Token like TokenKind or TokenText can parse the output of Lexer.
+// The positions in errors and Span are the positions in the input of Lexer, instead of the index of tokens.
+type Lexer struct {
+	rules []LexRule
+}
+
+// NewLexer makes a new Lexer that uses the `rules`.
+//
+// At each position, Lexer tries all rules and uses the rule that matches to the longest input.
+// If some rules matched the same length, the first one is used, so please place keywords before identifiers.
+// Rules that match to the empty input are ignored.
+func NewLexer(rules ...LexRule) *Lexer {
+	return &Lexer{rules}
+}
+
+// Lex splits `input` into tokens.
+//
+// It returns ErrInvalidInputVerbose if no rule matched, or ErrInvalidInput if Verbose(false) is set.
+// If a rule failed after Commit, the error is returned as is.
+func (l *Lexer) Lex(input []rune, options ...Option) ([]Token, error) {
+	s := NewSession(input, options...)
+	defer s.Close()
+
+	var tokens []Token
+	remain := s.Input()
+	for len(remain) > 0 {
+		rule, r, err := l.match(remain, s.config.verbose)
+		if err != nil {
+			return nil, err
+		}
+
+		if !rule.skip {
+			tokens = append(tokens, Token{
+				Kind: rule.kind,
+				Text: string(remain[:len(remain)-len(r)]),
+				Span: Span{s.Position(remain), s.Position(r)},
+			})
+		}
+		remain = r
+	}
+
+	return tokens, nil
+}
+
+// LexString splits string `input` into tokens.
+func (l *Lexer) LexString(input string, options ...Option) ([]Token, error) {
+	return l.Lex([]rune(input), options...)
+}
+
+// match finds the rule that matches to the longest input.
+func (l *Lexer) match(input []rune, verbose bool) (rule LexRule, remain []rune, err error) {
+	found := false
+	for _, r := range l.rules {
+		_, rem, err := r.parser.Parse(input, false)
+		if isFatal(err) {
+			return rule, input, err
+		}
+		if err != nil || len(rem) == len(input) {
+			continue
+		}
+
+		if !found || len(rem) < len(remain) {
+			rule, remain, found = r, rem, true
+		}
+	}
+
+	if !found {
+		if !verbose {
+			return rule, input, ErrInvalidInput
+		}
+
+		var expected ExpectedSet
+		for _, r := range l.rules {
+			expected = expected.add(r.kind)
+		}
+		return rule, input, newError(expected, input, true)
+	}
+	return rule, remain, nil
+}
+
+type tokenParser struct {
+	TokenKind string
+	Text      string
+	MatchText bool
+}
+
+// TokenKind parses a token of the `kind`.
+func TokenKind(kind string) Parser[Token, Token] {
+	return tokenParser{TokenKind: kind}
+}
+
+// TokenText parses a token of the `kind` that has the `text`, like a keyword or an operator.
+func TokenText(kind, text string) Parser[Token, Token] {
+	return tokenParser{kind, text, true}
+}
+
+func (t tokenParser) Parse(input []Token, verbose bool) (output Token, remain []Token, err error) {
+	if tracing() {
+		defer trace("Token", t, input).leave(&remain, &err)
+	}
+
+	if len(input) == 0 || input[0].Kind != t.TokenKind || (t.MatchText && input[0].Text != t.Text) {
+		err = newError(t, input, verbose)
+		return
+	}
+	return input[0], input[1:], nil
+}
+
+func (t tokenParser) String() string {
+	if t.MatchText {
+		return strconv.Quote(t.Text)
+	}
+	return t.TokenKind
+}
+
+func (t tokenParser) Kind() Kind {
+	return KindToken
+}
+
+func (t tokenParser) Children() []Node {
+	return nil
+}
@@ -0,0 +1,86 @@
+package parcon_test
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/macrat/parcon"
+)
+
+func ExampleLexer() {
+	lexer := parcon.NewLexer(
+		parcon.TokenRule("LET", parcon.Regexp("LET", `let\b`)),
+		parcon.TokenRule("IDENT", parcon.Regexp("IDENT", `[a-z]+`)),
+		parcon.TokenRule("NUMBER", parcon.Regexp("NUMBER", `[0-9]+`)),
+		parcon.TokenRule("SYMBOL", parcon.OneOf("SYMBOL", []rune("=+;"))),
+		parcon.SkipRule("SPACE", parcon.MultiSpacesOrNewlines),
+		parcon.SkipRule("COMMENT", parcon.Regexp("COMMENT", `#.*`)),
+	)
+
+	tokens, err := lexer.LexString("let x = 1 + 23; # comment\nlet letter = x;")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, t := range tokens {
+		fmt.Println(t, t.Span)
+	}
+
+	_, err = lexer.LexString("let x = 1 - 2;")
+	fmt.Println(err)
+
+	_, err = lexer.LexString("let x = 1 - 2;", parcon.Verbose(false))
+	fmt.Println(err)
+
+	// OUTPUT:
+	// LET "let" 1:1-1:4
+	// IDENT "x" 1:5-1:6
+	// SYMBOL "=" 1:7-1:8
+	// NUMBER "1" 1:9-1:10
+	// SYMBOL "+" 1:11-1:12
+	// NUMBER "23" 1:13-1:15
+	// SYMBOL ";" 1:15-1:16
+	// LET "let" 2:1-2:4
+	// IDENT "letter" 2:5-2:11
+	// SYMBOL "=" 2:12-2:13
+	// IDENT "x" 2:14-2:15
+	// SYMBOL ";" 2:15-2:16
+	// invalid input at 1:11: expected LET, IDENT, NUMBER, SYMBOL, SPACE, or COMMENT but got "-"
+	// invalid input
+}
+
+func ExampleTokenText() {
+	lexer := parcon.NewLexer(
+		parcon.TokenRule("NUMBER", parcon.Regexp("NUMBER", `[0-9]+`)),
+		parcon.TokenRule("SYMBOL", parcon.OneOf("SYMBOL", []rune("+*()"))),
+		parcon.SkipRule("SPACE", parcon.MultiSpacesOrNewlines),
+	)
+
+	// The parser does not need to care about spaces, because the lexer skips them.
+	number := parcon.Convert(parcon.TokenKind("NUMBER"), func(t parcon.Token) (int, error) {
+		return strconv.Atoi(t.Text)
+	})
+	sum := parcon.Convert(
+		parcon.SeparatedList(1, parcon.TokenText("SYMBOL", "+"), number),
+		func(xs []int) (int, error) {
+			n := 0
+			for _, x := range xs {
+				n += x
+			}
+			return n, nil
+		},
+	)
+
+	for _, input := range []string{"1 + 2\n+ 3", "1 + + 2"} {
+		tokens, err := lexer.LexString(input)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		fmt.Println(parcon.ParseAll(sum, tokens, parcon.Verbose(true)))
+	}
+
+	// OUTPUT:
+	// 6 <nil>
+	// 0 invalid input at 1:5: expected NUMBER but got SYMBOL "+"
+}
@@ -0,0 +1,126 @@
+package parcon
+
+import (
+	"context"
+	"errors"
+)
+
+// Errors when parsing exceeded a limit.
+// Parsers that backtrack, like Or, Optional, or Many, do not try other choices if they got these errors.
+var (
+	// ErrDepthExceeded is an error when the nesting depth of Ref, Lazy, or Memo exceeded MaxDepth.
+	ErrDepthExceeded = errors.New("parcon: nesting depth exceeded")
+
+	// ErrStepsExceeded is an error when the number of steps exceeded MaxSteps.
+	ErrStepsExceeded = errors.New("parcon: number of steps exceeded")
+
+	// ErrOutputTooLarge is an error when the number of elements in the output exceeded MaxOutputSize.
+	ErrOutputTooLarge = errors.New("parcon: output too large")
+)
+
+// WithContext sets a context.Context to cancel parsing.
+//
+// Parsers check the context at some steps, and return the error of the context like context.Canceled or context.DeadlineExceeded.
+func WithContext(ctx context.Context) Option {
+	return func(c *config) {
+		c.ctx = ctx
+	}
+}
+
+// MaxDepth sets the maximum nesting depth of recursive parsers. The default is 0 that means no limit.
+//
+// The depth is counted by Ref, Lazy, and Memo, because a recursive grammar has to use one of them.
+// Parsers return ErrDepthExceeded if the depth exceeded the limit.
+func MaxDepth(depth int) Option {
+	return func(c *config) {
+		c.maxDepth = depth
+	}
+}
+
+// MaxSteps sets the maximum number of steps to parse. The default is 0 that means no limit.
+//
+// A step is a try of a parser that may backtrack or recurse, like an alternative of Or, an element of Many, or a call of Ref.
+// Parsers return ErrStepsExceeded if the number of steps exceeded the limit.
+func MaxSteps(steps int) Option {
+	return func(c *config) {
+		c.maxSteps = steps
+	}
+}
+
+// MaxOutputSize sets the maximum number of elements in the outputs of Many, SeparatedList, and Repeat. The default is 0 that means no limit.
+//
+// The size is the total of all lists in the output, and elements that discarded by backtracking are not counted.
+// Parsers return ErrOutputTooLarge if the size exceeded the limit.
+func MaxOutputSize(size int) Option {
+	return func(c *config) {
+		c.maxOutputSize = size
+	}
+}
+
+// isAborted checks if `err` is an error that aborts parsing, like ErrDepthExceeded or context.Canceled.
+func isAborted(err error) bool {
+	switch err {
+	case ErrDepthExceeded, ErrStepsExceeded, ErrOutputTooLarge, context.Canceled, context.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
+
+// step counts a step of parsing, and checks the limit and the context.
+// It is safe to call this method of nil.
+func (s *Session[I]) step() error {
+	if s == nil {
+		return nil
+	}
+
+	s.steps++
+	if s.config.maxSteps > 0 && s.steps > s.config.maxSteps {
+		return ErrStepsExceeded
+	}
+
+	// Checking the context is not cheap, so check it only sometimes.
+	if s.config.ctx != nil && s.steps%256 == 1 {
+		return s.config.ctx.Err()
+	}
+	return nil
+}
+
+// enter counts a step and the nesting depth.
+// Please call leave after parsing if enter succeed.
+// It is safe to call this method of nil.
+func (s *Session[I]) enter() error {
+	if s == nil {
+		return nil
+	}
+
+	if s.config.maxDepth > 0 && s.depth >= s.config.maxDepth {
+		return ErrDepthExceeded
+	}
+	if err := s.step(); err != nil {
+		return err
+	}
+	s.depth++
+	return nil
+}
+
+// leave is the opposite of enter.
+func (s *Session[I]) leave() {
+	if s != nil {
+		s.depth--
+	}
+}
+
+// emit counts an element of output, and checks the limit.
+// It is safe to call this method of nil.
+func (s *Session[I]) emit() error {
+	if s == nil {
+		return nil
+	}
+
+	s.outputs++
+	if s.config.maxOutputSize > 0 && s.outputs > s.config.maxOutputSize {
+		return ErrOutputTooLarge
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package parcon_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/macrat/parcon"
+)
+
+// nestedParser parses nested brackets like "[[[]]]", and returns the depth.
+func nestedParser() parcon.Parser[rune, int] {
+	var ref parcon.Ref[rune, int]
+	ref.Set(parcon.Named("BRACKETS", parcon.Convert(
+		parcon.WithEnclosure(
+			parcon.TagStr("BEGIN", "["),
+			parcon.Optional[rune, int](&ref),
+			parcon.TagStr("END", "]"),
+		),
+		func(depth int) (int, error) {
+			return depth + 1, nil
+		},
+	)))
+	return &ref
+}
+
+func ExampleMaxDepth() {
+	parser := nestedParser()
+
+	output, err := parcon.ParseString(parser, "[[[]]]", parcon.MaxDepth(10))
+	fmt.Printf("output:%v err:%v\n", output, err)
+
+	_, err = parcon.ParseString(parser, strings.Repeat("[", 100)+strings.Repeat("]", 100), parcon.MaxDepth(10))
+	fmt.Println(errors.Is(err, parcon.ErrDepthExceeded))
+
+	// OUTPUT:
+	// output:3 err:<nil>
+	// true
+}
+
+func ExampleMaxSteps() {
+	// This grammar takes exponential time for input like "aaaa...ac", because it tries both alternatives at each "a".
+	var ref parcon.Ref[rune, string]
+	var parser parcon.Parser[rune, string] = &ref
+	a := parcon.TagStr("A", "a")
+	ref.Set(parcon.Named("S", parcon.Or(
+		parcon.WithPrefix(a, parcon.WithSuffix(parser, a)),
+		parcon.WithPrefix(a, parser),
+		parcon.TagStr("B", "b"),
+	)))
+
+	_, err := parcon.ParseString(parser, strings.Repeat("a", 30)+"c", parcon.MaxSteps(10000))
+	fmt.Println(err)
+
+	// OUTPUT:
+	// parcon: number of steps exceeded
+}
+
+func ExampleMaxOutputSize() {
+	parser := parcon.SeparatedList(0, parcon.TagStr("COMMA", ","), parcon.MultiDigits)
+
+	output, err := parcon.ParseString(parser, "1,2,3", parcon.MaxOutputSize(3))
+	fmt.Printf("len:%d err:%v\n", len(output), err)
+
+	_, err = parcon.ParseString(parser, "1,2,3,4", parcon.MaxOutputSize(3))
+	fmt.Printf("err:%v\n", err)
+
+	// OUTPUT:
+	// len:3 err:<nil>
+	// err:parcon: output too large
+}
+
+func ExampleWithContext() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := parcon.ParseString(nestedParser(), "[[[]]]", parcon.WithContext(ctx))
+	fmt.Println(err)
+
+	// OUTPUT:
+	// context canceled
+}
@@ -0,0 +1,126 @@
+package parcon
+
+import (
+	"fmt"
+)
+
+type peekParser[I comparable, O any] struct {
+	Parser Parser[I, O]
+}
+
+// Peek parses input using the given `parser`, but does not consume the input.
+// It returns the output of `parser` and the whole of the input as `remain`.
+func Peek[I comparable, O any](parser Parser[I, O]) Parser[I, O] {
+	return peekParser[I, O]{parser}
+}
+
+func (p peekParser[I, O]) Parse(input []I, verbose bool) (output O, remain []I, err error) {
+	if tracing() {
+		defer trace("Peek", p, input).leave(&remain, &err)
+	}
+
+	s := sessionOf(input)
+	cp := s.checkpoint()
+
+	output, _, err = p.Parser.Parse(input, verbose)
+	if !isFatal(err) {
+		s.rollback(cp)
+	}
+	return output, input, err
+}
+
+func (p peekParser[I, O]) String() string {
+	return fmt.Sprint(p.Parser)
+}
+
+func (p peekParser[I, O]) Kind() Kind {
+	return KindPeek
+}
+
+func (p peekParser[I, O]) Children() []Node {
+	return nodesOf(p.Parser)
+}
+
+type notParser[I comparable, O any] struct {
+	Parser Parser[I, O]
+}
+
+// Not succeeds only if the given `parser` fails, and it does not consume the input.
+//
+// For example, you can use it to parse keywords that are not a part of identifier like below.
+//
+//	parcon.WithSuffix(parcon.TagStr("IF", "if"), parcon.Not(parcon.SingleAlphaNum))
+func Not[I comparable, O any](parser Parser[I, O]) Parser[I, struct{}] {
+	return notParser[I, O]{parser}
+}
+
+func (n notParser[I, O]) Parse(input []I, verbose bool) (output struct{}, remain []I, err error) {
+	if tracing() {
+		defer trace("Not", n, input).leave(&remain, &err)
+	}
+
+	s := sessionOf(input)
+	cp := s.checkpoint()
+	var prev failure[I]
+	if s != nil {
+		prev = s.furthest
+	}
+
+	if _, _, err = n.Parser.Parse(input, false); isFatal(err) {
+		return struct{}{}, input, err
+	}
+	s.rollback(cp)
+	if s != nil && s.tracking {
+		// Failures of the parser are expected, so they are not the furthest failure.
+		s.furthest = prev
+	}
+
+	if err != nil {
+		return struct{}{}, input, nil
+	}
+	return struct{}{}, input, newError(n, input, verbose)
+}
+
+func (n notParser[I, O]) String() string {
+	return fmt.Sprintf("not [%v]", n.Parser)
+}
+
+func (n notParser[I, O]) Kind() Kind {
+	return KindNot
+}
+
+func (n notParser[I, O]) Children() []Node {
+	return nodesOf(n.Parser)
+}
+
+type eofParser[I comparable] struct{}
+
+// EOF succeeds only if there is no more input.
+func EOF[I comparable]() Parser[I, struct{}] {
+	return eofParser[I]{}
+}
+
+func (e eofParser[I]) Parse(input []I, verbose bool) (output struct{}, remain []I, err error) {
+	if tracing() {
+		defer trace("EOF", e, input).leave(&remain, &err)
+	}
+
+	if len(input) != 0 {
+		err = newError(e, input, verbose)
+	} else {
+		reachEnd(input)
+	}
+	return struct{}{}, input, err
+}
+
+func (e eofParser[I]) String() string {
+	return "EOF"
+}
+
+func (e eofParser[I]) Kind() Kind {
+	return KindEOF
+}
+
+func (e eofParser[I]) Children() []Node {
+	return nil
+}
@@ -0,0 +1,54 @@
+package parcon_test
+
+import (
+	"fmt"
+
+	"github.com/macrat/parcon"
+)
+
+func ExamplePeek() {
+	parser := parcon.Peek(parcon.TagStr("HELLO", "hello"))
+
+	output, remain, err := parser.Parse([]rune("hello world"), true)
+	fmt.Printf("output:%#v remain:%#v err:%v\n", output, string(remain), err)
+
+	_, _, err = parser.Parse([]rune("world"), true)
+	fmt.Printf("err:%v\n", err)
+
+	// OUTPUT:
+	// output:"hello" remain:"hello world" err:<nil>
+	// err:invalid input: expected HELLO but got "w"
+}
+
+func ExampleNot() {
+	keyword := parcon.WithSuffix(parcon.TagStr("IF", "if"), parcon.Not(parcon.SingleAlphaNum))
+
+	output, remain, err := keyword.Parse([]rune("if x"), true)
+	fmt.Printf("output:%#v remain:%#v err:%v\n", output, string(remain), err)
+
+	_, _, err = keyword.Parse([]rune("iffy"), true)
+	fmt.Printf("err:%v\n", err)
+
+	// The failure of Not is reported as the furthest one, but the failures of the inner parser are not.
+	_, err = parcon.ParseString(parcon.Or(keyword, parcon.TagStr("ELSE", "else")), "ifx")
+	fmt.Printf("err:%v\n", err)
+
+	// OUTPUT:
+	// output:"if" remain:" x" err:<nil>
+	// err:invalid input: expected not [ALPHA_NUM] but got "f"
+	// err:invalid input at 1:3: expected not [ALPHA_NUM] but got "x"
+}
+
+func ExampleEOF() {
+	parser := parcon.WithSuffix(parcon.MultiDigits, parcon.EOF[rune]())
+
+	output, remain, err := parser.Parse([]rune("123"), true)
+	fmt.Printf("output:%#v remain:%#v err:%v\n", string(output), string(remain), err)
+
+	_, _, err = parser.Parse([]rune("123abc"), true)
+	fmt.Printf("err:%v\n", err)
+
+	// OUTPUT:
+	// output:"123" remain:"" err:<nil>
+	// err:invalid input: expected EOF but got "a"
+}
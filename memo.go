@@ -0,0 +1,188 @@
+package parcon
+
+import (
+	"fmt"
+)
+
+type memoKey struct {
+	Parser any
+	Offset int
+	Length int
+	State  int
+	Indent string
+}
+
+type memoEntry[I comparable] struct {
+	Output  any
+	Remain  []I
+	Err     error
+	Verbose bool
+
+	// Errors is errors that recovered by Recover while making this entry.
+	Errors ErrorList
+
+	// State and StateID are the user state after parsing.
+	State   any
+	StateID int
+
+	// InProgress is true while parsing to make this entry.
+	// If the parser found an entry that in progress, it is a left recursion.
+	InProgress bool
+
+	// LeftRecursive is true if found a left recursion while making this entry.
+	LeftRecursive bool
+
+	// Involved is true if the result depends on an unfinished left recursion.
+	// This entry can not be memoized because the result will be changed while growing the left recursion.
+	Involved bool
+}
+
+type memoParser[I comparable, O any] struct {
+	Parser Parser[I, O]
+}
+
+// Memo memoizes results of the given `parser` for each position of the input. It is also known as Packrat parsing.
+//
+// Memoization makes grammars that backtrack a lot, like Or that has alternatives with the same prefix, to parse in linear time.
+// But it also makes simple grammars slower, because of the overhead of the memo table.
+//
+// Memo also supports left recursive grammars like `expr := expr "+" term | term`, using seed growing.
+// The recursion has to go through the same Memo parser, like below.
+//
+//	var ref parcon.Ref[rune, int]
+//	expr := parcon.Memo[rune, int](&ref)
+//	ref.Set(parcon.Or(
+//		parcon.Convert(parcon.Sequence(expr, parcon.WithPrefix(plus, term)), add),
+//		term,
+//	))
+//
+// The memo table is owned by Session.
+// If the parser is not used in a Session, Memo makes a new Session for each call.
+// So left recursive grammars work even without Session, but the memo table is not shared between calls, and errors do not have Position.
+func Memo[I comparable, O any](parser Parser[I, O]) Parser[I, O] {
+	return &memoParser[I, O]{parser}
+}
+
+func (m *memoParser[I, O]) Parse(input []I, verbose bool) (output O, remain []I, err error) {
+	s := sessionOf(input)
+	if s == nil {
+		return m.parseDetached(input, verbose)
+	}
+
+	if err = s.enter(); err != nil {
+		return
+	}
+	defer s.leave()
+
+	offset := cap(s.input) - cap(input)
+	key := memoKey{m, offset, len(input), s.stateID, s.currentIndent()}
+
+	if e, ok := s.memo[key]; ok {
+		if e.InProgress {
+			e.LeftRecursive = true
+			s.involve(e)
+		}
+		if e.InProgress || e.Err == nil || e.Verbose || !verbose {
+			s.errors = append(s.errors, e.Errors...)
+			s.state, s.stateID = e.State, e.StateID
+			output, _ = e.Output.(O)
+			return output, e.Remain, e.Err
+		}
+	}
+
+	if s.memo == nil {
+		s.memo = make(map[memoKey]*memoEntry[I])
+		s.growing = make(map[int]int)
+	}
+
+	// The seed of left recursion is a failure that expects nothing, so it does not appear in errors of Or.
+	seed := invalidInput(ExpectedSet{}, input, verbose)
+	e := &memoEntry[I]{Err: seed, Verbose: verbose, InProgress: true, State: s.state, StateID: s.stateID}
+	s.memo[key] = e
+
+	cp := s.checkpoint()
+
+	s.memoStack = append(s.memoStack, e)
+	output, remain, err = m.Parser.Parse(input, verbose)
+	s.memoStack = s.memoStack[:len(s.memoStack)-1]
+
+	e.Output, e.Remain, e.Err = output, remain, err
+	e.Errors = append(ErrorList(nil), s.errors[cp.errors:]...)
+	e.State, e.StateID = s.state, s.stateID
+	e.InProgress = false
+
+	if e.LeftRecursive && err == nil {
+		// Grow the seed until the parser can not consume more input.
+		s.growing[offset]++
+		for {
+			grown := s.checkpoint()
+			s.state, s.stateID = cp.state, cp.stateID
+			o, r, err := m.Parser.Parse(input, verbose)
+			if err != nil || len(r) >= len(e.Remain) {
+				s.rollback(grown)
+				break
+			}
+			e.Output, e.Remain = o, r
+			e.Errors = append(ErrorList(nil), s.errors[grown.errors:]...)
+			e.State, e.StateID = s.state, s.stateID
+			s.errors = append(s.errors[:cp.errors], e.Errors...)
+		}
+		s.growing[offset]--
+
+		output, _ = e.Output.(O)
+		remain = e.Remain
+	}
+
+	if e.Involved || (!e.LeftRecursive && s.growing[offset] > 0) {
+		delete(s.memo, key)
+	}
+
+	return
+}
+
+// parseDetached parses `input` that is not a part of any Session, in a new Session for this call only.
+// The results are converted to refer to `input` instead of the copy in the Session.
+func (m *memoParser[I, O]) parseDetached(input []I, verbose bool) (output O, remain []I, err error) {
+	s := NewSession(input)
+	defer s.Close()
+
+	cp := s.checkpoint()
+	output, remain, err = m.Parse(s.Input(), verbose)
+	if err != nil && verbose {
+		err = s.explain(err, cp, func() {
+			m.Parse(s.Input(), true)
+		})
+	}
+	if remain != nil {
+		remain = input[len(input)-len(remain):]
+	}
+
+	if err == nil && len(s.errors) > 0 {
+		// Recover does not recover errors without Session, so the recovered errors are returned.
+		err = s.errors
+		if len(s.errors) == 1 {
+			err = s.errors[0]
+		}
+	}
+	if err != nil {
+		// Errors refer to `input` without Position, as the same as errors of parsers that not used in a Session.
+		err = mapVerboseErrors(err, func(e ErrInvalidInputVerbose[I]) ErrInvalidInputVerbose[I] {
+			e.Input = input[len(input)-len(e.Input):]
+			e.Position = nil
+			return e
+		})
+	}
+	return
+}
+
+func (m *memoParser[I, O]) String() string {
+	return fmt.Sprint(m.Parser)
+}
+
+func (m *memoParser[I, O]) Kind() Kind {
+	return KindMemo
+}
+
+func (m *memoParser[I, O]) Children() []Node {
+	return nodesOf(m.Parser)
+}
@@ -0,0 +1,126 @@
+package parcon_test
+
+import (
+	"fmt"
+
+	"github.com/macrat/parcon"
+)
+
+func ExampleMemo() {
+	count := 0
+	word := parcon.Func(func(input []rune, verbose bool) ([]rune, []rune, error) {
+		count++
+		return parcon.MultiAlphas.Parse(input, verbose)
+	})
+
+	parser := func(word parcon.Parser[rune, []rune]) parcon.Parser[rune, []rune] {
+		return parcon.Or(
+			parcon.WithSuffix(word, parcon.TagStr("QUESTION", "?")),
+			parcon.WithSuffix(word, parcon.TagStr("EXCLAMATION", "!")),
+			parcon.WithSuffix(word, parcon.TagStr("PERIOD", ".")),
+		)
+	}
+
+	session := parcon.NewSession([]rune("hello."))
+	defer session.Close()
+
+	count = 0
+	output, _, _ := parser(word).Parse(session.Input(), true)
+	fmt.Printf("without memo: output:%#v count:%d\n", string(output), count)
+
+	count = 0
+	output, _, _ = parser(parcon.Memo(word)).Parse(session.Input(), true)
+	fmt.Printf("with memo: output:%#v count:%d\n", string(output), count)
+
+	// OUTPUT:
+	// without memo: output:"hello" count:3
+	// with memo: output:"hello" count:1
+}
+
+func ExampleMemo_leftRecursion() {
+	// expr := expr "-" number | number
+	var ref parcon.Ref[rune, int]
+	expr := parcon.Memo[rune, int](&ref)
+
+	number := parcon.Convert(parcon.MultiDigits, parcon.ToInt)
+
+	ref.Set(parcon.Or(
+		parcon.Convert(
+			parcon.Pair(expr, parcon.WithPrefix(parcon.TagStr("MINUS", "-"), number)),
+			func(x parcon.PairValue[int, int]) (int, error) {
+				return x.First - x.Second, nil
+			},
+		),
+		number,
+	))
+
+	session := parcon.NewSession([]rune("10-3-2"))
+	defer session.Close()
+
+	output, remain, err := expr.Parse(session.Input(), true)
+	fmt.Printf("output:%#v remain:%#v err:%v\n", output, string(remain), err)
+
+	_, err = parcon.ParseString(expr, "10-x")
+	fmt.Printf("err:%v\n", err)
+
+	// OUTPUT:
+	// output:5 remain:"" err:<nil>
+	// err:invalid input at 1:4: expected DIGIT but got "x"
+}
+
+func ExampleMemo_indirectLeftRecursion() {
+	// call := primary "(" ")"
+	// primary := call | NAME
+	var ref parcon.Ref[rune, string]
+	primary := parcon.Memo[rune, string](&ref)
+
+	call := parcon.Memo(parcon.Convert(
+		parcon.WithSuffix(primary, parcon.TagStr("PARENTHESES", "()")),
+		func(s string) (string, error) {
+			return "call(" + s + ")", nil
+		},
+	))
+
+	ref.Set(parcon.Or(
+		call,
+		parcon.Convert(parcon.MultiAlphas, parcon.ToString),
+	))
+
+	session := parcon.NewSession([]rune("f()()"))
+	defer session.Close()
+
+	output, remain, err := primary.Parse(session.Input(), true)
+	fmt.Printf("output:%#v remain:%#v err:%v\n", output, string(remain), err)
+
+	// OUTPUT:
+	// output:"call(call(f))" remain:"" err:<nil>
+}
+
+func ExampleMemo_withoutSession() {
+	// expr := expr "-" number | number
+	var ref parcon.Ref[rune, int]
+	expr := parcon.Memo[rune, int](&ref)
+
+	number := parcon.Convert(parcon.MultiDigits, parcon.ToInt)
+
+	ref.Set(parcon.Or(
+		parcon.Convert(
+			parcon.Pair(expr, parcon.WithPrefix(parcon.TagStr("MINUS", "-"), number)),
+			func(x parcon.PairValue[int, int]) (int, error) {
+				return x.First - x.Second, nil
+			},
+		),
+		number,
+	))
+
+	// Memo makes a Session for the call, so the left recursion works without Session.
+	output, remain, err := expr.Parse([]rune("10-3-2+1"), true)
+	fmt.Printf("output:%#v remain:%#v err:%v\n", output, string(remain), err)
+
+	_, _, err = expr.Parse([]rune("x"), true)
+	fmt.Printf("err:%v\n", err)
+
+	// OUTPUT:
+	// output:5 remain:"+1" err:<nil>
+	// err:invalid input: expected DIGIT but got "x"
+}
@@ -39,14 +39,56 @@ type named[I comparable, O any] struct {
 }
 
 // Named sets parser's name that shown in error message.
+//
+// If the `parser` failed without consuming input, the error says that expected `name`.
+// The original error can be got using errors.Unwrap.
+// If the `parser` failed after consuming some input, the error is reported as is because it is more precise.
 func Named[I comparable, O any](name string, parser Parser[I, O]) Parser[I, O] {
-	return named[I, O]{name, parser}
+	return &named[I, O]{name, parser}
+}
+
+// relabelFurthest replaces the expected things of the furthest failure with the name, if the failure is at the beginning of `input`.
+// The `prev` is the furthest failure before parsing, to keep things that recorded by other parsers.
+func (n *named[I, O]) relabelFurthest(s *Session[I], input []I, prev failure[I]) {
+	if !s.furthest.at(input) {
+		return
+	}
+	if !prev.at(input) {
+		prev.Expected = nil
+	}
+	s.furthest.Expected = prev.Expected[:len(prev.Expected):len(prev.Expected)].add(n.Name)
 }
 
-func (n named[I, O]) String() string {
+func (n *named[I, O]) String() string {
 	return n.Name
 }
 
-func (n named[I, O]) Parse(input []I, verbose bool) (output O, remain []I, err error) {
-	return n.Parser.Parse(input, verbose)
+func (n *named[I, O]) Kind() Kind {
+	return KindNamed
+}
+
+func (n *named[I, O]) Children() []Node {
+	return nodesOf(n.Parser)
+}
+
+func (n *named[I, O]) Parse(input []I, verbose bool) (output O, remain []I, err error) {
+	if tracing() {
+		defer trace("Named", n, input).leave(&remain, &err)
+	}
+
+	s := sessionOf(input)
+	if s != nil && s.tracking {
+		defer n.relabelFurthest(s, input, s.furthest)
+	}
+
+	output, remain, err = n.Parser.Parse(input, verbose)
+	if e, ok := err.(ErrInvalidInputVerbose[I]); ok && len(e.Input) == len(input) {
+		err = ErrInvalidInputVerbose[I]{
+			Expected: n.Name,
+			Input:    input,
+			Position: e.Position,
+			Err:      e,
+		}
+	}
+	return
 }
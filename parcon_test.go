@@ -1,6 +1,7 @@
 package parcon_test
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/macrat/parcon"
@@ -47,6 +48,23 @@ func ExampleNamed() {
 	fmt.Println("named:", err)
 
 	// OUTPUT:
-	// raw: invalid input: expected one of [AT_SYMBOL, ALPHA, NOTHING] [HASH_SYMBOL, ALPHA, NOTHING] but got "hello"
-	// named: invalid input: expected one of [MENTION] [TAG] but got "hello"
+	// raw: invalid input: expected AT_SYMBOL, ALPHA, NOTHING or HASH_SYMBOL, ALPHA, NOTHING but got "h"
+	// named: invalid input: expected MENTION or TAG but got "h"
+}
+
+func ExampleNamed_unwrap() {
+	parser := parcon.Named("GREETING", parcon.Or(
+		parcon.TagStr("HELLO", "hello"),
+		parcon.TagStr("HI", "hi"),
+	))
+
+	_, _, err := parser.Parse([]rune("bye"), true)
+	fmt.Println(err)
+	fmt.Println(errors.Unwrap(err))
+	fmt.Println(errors.Is(err, parcon.ErrInvalidInput))
+
+	// OUTPUT:
+	// invalid input: expected GREETING but got "b"
+	// invalid input: expected HELLO or HI but got "b"
+	// true
 }
@@ -0,0 +1,133 @@
+package parcon
+
+import (
+	"context"
+	"unicode/utf8"
+)
+
+// Option is an option for ParseAll, ParseString, and NewSession.
+type Option func(*config)
+
+type config struct {
+	verbose      bool
+	allowPartial bool
+	utf8Text     bool
+	state        any
+
+	ctx           context.Context
+	maxDepth      int
+	maxSteps      int
+	maxOutputSize int
+
+	tracer Tracer
+}
+
+func newConfig(options []Option) config {
+	c := config{
+		verbose: true,
+	}
+	for _, o := range options {
+		o(&c)
+	}
+	return c
+}
+
+// Verbose sets whether parsers make detailed errors or not. The default is true.
+//
+// Parsers are faster in non-verbose mode, but errors do not have any detail like the position.
+func Verbose(verbose bool) Option {
+	return func(c *config) {
+		c.verbose = verbose
+	}
+}
+
+// AllowPartial sets whether ParseAll and ParseString allow remaining input after parse or not. The default is false.
+func AllowPartial(allow bool) Option {
+	return func(c *config) {
+		c.allowPartial = allow
+	}
+}
+
+// UTF8Text sets whether the []byte input is UTF-8 encoded text or not. The default is false.
+//
+// If it is true, Position of []byte input has the line and column, and the column is counted in characters.
+// ParseString sets it true for []byte parsers, because the input is a string.
+func UTF8Text(enable bool) Option {
+	return func(c *config) {
+		c.utf8Text = enable
+	}
+}
+
+// ParseAll parses the whole of `input` using the given `parser` in a new Session.
+//
+// It returns ErrInvalidInputVerbose that expects EOF if some input remains, unless AllowPartial is set.
+// The errors have a Position in verbose mode.
+//
+// In verbose mode, the error is reported at the furthest position that any parser failed, with all of things that expected at there.
+// For example, the error says that "," or "]" is expected if a list like "[1, 2 3]" is broken, even if the list parser succeed with "[1, 2" and a following parser failed.
+//
+// If some errors are recovered by Recover, it returns the output with an ErrorList that includes all errors.
+func ParseAll[I comparable, O any](parser Parser[I, O], input []I, options ...Option) (output O, err error) {
+	s := NewSession(input, options...)
+	defer s.Close()
+
+	return parseSession(parser, s)
+}
+
+// parseSession parses the whole of the input of Session `s` for ParseAll.
+func parseSession[I comparable, O any](parser Parser[I, O], s *Session[I]) (output O, err error) {
+	parse := func(verbose bool) (output O, err error) {
+		output, remain, err := parser.Parse(s.Input(), verbose)
+		if err == nil && len(remain) != 0 && !s.config.allowPartial {
+			err = newError(EOF[I](), remain, verbose)
+		}
+		return output, err
+	}
+
+	cp := s.checkpoint()
+	output, err = parse(s.config.verbose)
+	if err != nil && s.config.verbose {
+		err = s.explain(err, cp, func() {
+			parse(true)
+		})
+	}
+
+	if len(s.errors) > 0 {
+		if err != nil {
+			var zero O
+			return zero, append(s.errors, err)
+		}
+		return output, s.errors
+	}
+
+	if err != nil {
+		var zero O
+		return zero, err
+	}
+	return output, nil
+}
+
+// ParseString parses the whole of string `input` using the given `parser`.
+//
+// The `parser` can be a parser for []rune, or a parser for UTF-8 encoded []byte like ByteTagStr.
+// The parser for []byte is faster and uses less memory, and positions are reported in byte offsets.
+func ParseString[I rune | byte, O any](parser Parser[I, O], input string, options ...Option) (output O, err error) {
+	// Make a private array with extra capacity for Session, to avoid copy.
+	var xs []I
+	switch p := any(&xs).(type) {
+	case *[]rune:
+		*p = make([]rune, 0, utf8.RuneCountInString(input)+1)
+		for _, c := range input {
+			*p = append(*p, c)
+		}
+	case *[]byte:
+		*p = make([]byte, len(input), len(input)+1)
+		copy(*p, input)
+		options = append([]Option{UTF8Text(true)}, options...)
+	}
+
+	s := newOwnedSession(xs, options)
+	defer s.Close()
+
+	return parseSession(parser, s)
+}
@@ -0,0 +1,74 @@
+package parcon_test
+
+import (
+	"fmt"
+
+	"github.com/macrat/parcon"
+)
+
+func ExampleParseAll() {
+	parser := parcon.SeparatedList(0, parcon.TagStr("COMMA", ","), parcon.Convert(parcon.MultiDigits, parcon.ToInt))
+
+	output, err := parcon.ParseAll(parser, []rune("1,2,3"))
+	fmt.Printf("output:%#v err:%v\n", output, err)
+
+	_, err = parcon.ParseAll(parser, []rune("1,2\n3"))
+	fmt.Printf("err:%v\n", err)
+
+	_, err = parcon.ParseAll(parser, []rune("1,2\n3"), parcon.Verbose(false))
+	fmt.Printf("err:%v\n", err)
+
+	output, err = parcon.ParseAll(parser, []rune("1,2\n3"), parcon.AllowPartial(true))
+	fmt.Printf("output:%#v err:%v\n", output, err)
+
+	// OUTPUT:
+	// output:[]int{1, 2, 3} err:<nil>
+	// err:invalid input at 1:4: expected COMMA or EOF but got "\n"
+	// err:invalid input
+	// output:[]int{1, 2} err:<nil>
+}
+
+func ExampleParseAll_furthest() {
+	parser := parcon.WithEnclosure(
+		parcon.TagStr("OPEN", "["),
+		parcon.SeparatedList(0, parcon.TagStr("COMMA", ","), parcon.Convert(parcon.MultiDigits, parcon.ToInt)),
+		parcon.TagStr("CLOSE", "]"),
+	)
+
+	// SeparatedList stops before " ", and the failure of COMMA is reported with CLOSE.
+	_, err := parcon.ParseAll(parser, []rune("[1,2 3]"))
+	fmt.Println(err)
+
+	// OUTPUT:
+	// invalid input at 1:5: expected COMMA or CLOSE but got " "
+}
+
+func ExampleParseString() {
+	parser := parcon.Convert(parcon.MultiDigits, parcon.ToInt)
+
+	output, err := parcon.ParseString(parser, "123")
+	fmt.Printf("output:%#v err:%v\n", output, err)
+
+	_, err = parcon.ParseString(parser, "abc")
+	fmt.Printf("err:%v\n", err)
+
+	// OUTPUT:
+	// output:123 err:<nil>
+	// err:invalid input at 1:1: expected DIGIT but got "a"
+}
+
+func ExampleUTF8Text() {
+	parser := parcon.SeparatedList(0, parcon.ByteSingleNewline, parcon.ByteTagStr("HELLO", "héllo"))
+	input := []byte("héllo\nhéllo\nhallo")
+
+	// []byte is not treated as text by default, so the position is an offset in bytes.
+	_, err := parcon.ParseAll(parser, input)
+	fmt.Println(err)
+
+	_, err = parcon.ParseAll(parser, input, parcon.UTF8Text(true))
+	fmt.Println(err)
+
+	// OUTPUT:
+	// invalid input at offset 14: expected HELLO but got 104
+	// invalid input at 3:1: expected HELLO but got "h"
+}
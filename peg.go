@@ -0,0 +1,629 @@
+package parcon
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// CompilePEG compiles a grammar written in PEG into a parser.
+//
+// The grammar is a list of rules like below. The first rule is the start rule.
+//
+//	# A comment starts with "#".
+//	sum    <- number ("+" number)* {sum}
+//	number <- _ $[0-9]+ _          {number}
+//	_      <- [ \t]*
+//
+// The expressions and their outputs are below.
+//
+//	"abc" or 'abc'  a literal string. The output is the string.
+//	[a-z] or [^a-z] a character class. The output is the matched character as a string.
+//	.               any character. The output is the character as a string.
+//	name            a reference to the rule. The output is the output of the rule.
+//	(e)             a group. The output is the output of `e`.
+//	e1 e2           a sequence. The output is a []any, or the output of the element if it has only one element.
+//	e1 / e2         an ordered choice. The output is the output of the matched alternative.
+//	e?              an optional. The output is nil if not matched.
+//	e* or e+        a repetition. The output is a []any.
+//	&e or !e        a positive or negative lookahead. The output is nil.
+//	$e              a capture. The output is the string that matched to `e`.
+//
+// Literals and classes accept escape sequences like "\n", "\"", or "\u00e9".
+//
+// A sequence can have an action like `{name}` at the end.
+// The action converts the output of the sequence using the function in `actions` that has the same name, as the same as Convert.
+//
+// Each rule is wrapped with Named, so errors say the name of the rule, and EBNF, PEG, and Railroad can show the rules.
+// Left recursive rules like `a <- a "x" / "x"` are not supported, and CompilePEG returns an error for them.
+func CompilePEG(grammar string, actions map[string]ConvertFunc[any, any]) (Parser[rune, any], error) {
+	defs, err := parsePEG(grammar)
+	if err != nil {
+		return nil, err
+	}
+
+	c := pegCompiler{
+		rules:   make(map[string]*Ref[rune, any]),
+		actions: actions,
+	}
+	for _, d := range defs {
+		c.rules[d.Name] = &Ref[rune, any]{}
+	}
+	for _, d := range defs {
+		p, err := d.Expr.compile(c)
+		if err != nil {
+			return nil, err
+		}
+		c.rules[d.Name].Set(Named(d.Name, p))
+	}
+
+	return c.rules[defs[0].Name], nil
+}
+
+// parsePEG parses a PEG grammar into definitions of rules.
+func parsePEG(grammar string) ([]pegDefinition, error) {
+	defs, err := ParseString(pegGrammar, grammar, Verbose(true))
+	if err != nil {
+		return nil, fmt.Errorf("parcon: invalid PEG grammar: %w", err)
+	}
+
+	defined := make(map[string]bool)
+	for _, d := range defs {
+		if defined[d.Name] {
+			return nil, fmt.Errorf("parcon: rule %q is defined twice in PEG grammar", d.Name)
+		}
+		defined[d.Name] = true
+	}
+
+	if err := checkLeftRecursion(defs); err != nil {
+		return nil, err
+	}
+
+	return defs, nil
+}
+
+// checkLeftRecursion returns an error if a rule calls itself before consuming any input, directly or indirectly.
+// Such rules never end, because PEG parsers do not grow the seed like Memo.
+func checkLeftRecursion(defs []pegDefinition) error {
+	// Find rules that can match the empty input, until no more found.
+	empty := make(map[string]bool)
+	for changed := true; changed; {
+		changed = false
+		for _, d := range defs {
+			if _, nullable := d.Expr.leftCalls(empty); nullable && !empty[d.Name] {
+				empty[d.Name] = true
+				changed = true
+			}
+		}
+	}
+
+	calls := make(map[string][]string)
+	for _, d := range defs {
+		calls[d.Name], _ = d.Expr.leftCalls(empty)
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	states := make(map[string]int)
+	var stack []string
+
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch states[name] {
+		case visiting:
+			for i, x := range stack {
+				if x == name {
+					path := append(stack[i:len(stack):len(stack)], name)
+					return fmt.Errorf("parcon: left recursive rule %q in PEG grammar: %s", name, strings.Join(path, " -> "))
+				}
+			}
+		case visited:
+			return nil
+		}
+
+		states[name] = visiting
+		stack = append(stack, name)
+		for _, callee := range calls[name] {
+			if err := visit(callee); err != nil {
+				return err
+			}
+		}
+		stack = stack[:len(stack)-1]
+		states[name] = visited
+
+		return nil
+	}
+
+	for _, d := range defs {
+		if err := visit(d.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pegCompiler holds the rules and the actions while compiling a PEG grammar.
+type pegCompiler struct {
+	rules   map[string]*Ref[rune, any]
+	actions map[string]ConvertFunc[any, any]
+}
+
+// pegExpr is an expression in a PEG grammar.
+// String returns the expression in PEG notation.
+type pegExpr interface {
+	fmt.Stringer
+	compile(c pegCompiler) (Parser[rune, any], error)
+
+	// generate returns the body of a Go function for GenerateGo.
+	// The function only matches without making the output if `match` is true.
+	generate(g *goGenerator, match bool) (string, error)
+
+	// leftCalls returns names of rules that may be called before consuming any input, and whether the expression can match the empty input.
+	// The `empty` is whether each rule can match the empty input.
+	leftCalls(empty map[string]bool) (rules []string, nullable bool)
+}
+
+// Precedences of PEG expressions, from the lowest to the highest.
+const (
+	pegPrecChoice = iota
+	pegPrecSequence
+	pegPrecPrefix
+	pegPrecSuffix
+	pegPrecPrimary
+)
+
+// pegParen returns `e` in PEG notation, with parentheses if the precedence of `e` is lower than `prec`.
+func pegParen(e pegExpr, prec int) string {
+	p := pegPrecPrimary
+	switch e.(type) {
+	case pegChoice:
+		p = pegPrecChoice
+	case pegSequence:
+		p = pegPrecSequence
+	case pegPrefix:
+		p = pegPrecPrefix
+	case pegSuffix:
+		p = pegPrecSuffix
+	}
+	if p < prec {
+		return "(" + e.String() + ")"
+	}
+	return e.String()
+}
+
+type pegDefinition struct {
+	Name string
+	Expr pegExpr
+}
+
+type pegChoice []pegExpr
+
+func (e pegChoice) compile(c pegCompiler) (Parser[rune, any], error) {
+	ps := make([]Parser[rune, any], len(e))
+	for i, x := range e {
+		p, err := x.compile(c)
+		if err != nil {
+			return nil, err
+		}
+		ps[i] = p
+	}
+	return Or(ps...), nil
+}
+
+func (e pegChoice) leftCalls(empty map[string]bool) (rules []string, nullable bool) {
+	for _, x := range e {
+		rs, n := x.leftCalls(empty)
+		rules = append(rules, rs...)
+		nullable = nullable || n
+	}
+	return rules, nullable
+}
+
+func (e pegChoice) String() string {
+	ss := make([]string, len(e))
+	for i, x := range e {
+		ss[i] = pegParen(x, pegPrecSequence)
+	}
+	return strings.Join(ss, " / ")
+}
+
+type pegSequence struct {
+	Items  []pegExpr
+	Action string
+}
+
+func (e pegSequence) compile(c pegCompiler) (Parser[rune, any], error) {
+	ps := make([]Parser[rune, any], len(e.Items))
+	for i, x := range e.Items {
+		p, err := x.compile(c)
+		if err != nil {
+			return nil, err
+		}
+		ps[i] = p
+	}
+
+	var p Parser[rune, any]
+	switch len(ps) {
+	case 0:
+		p = Replace[rune, struct{}, any](Nothing[rune](), nil)
+	case 1:
+		p = ps[0]
+	default:
+		p = Convert(Sequence(ps...), toAny[[]any])
+	}
+
+	if e.Action == "" {
+		return p, nil
+	}
+	fn, ok := c.actions[e.Action]
+	if !ok {
+		return nil, fmt.Errorf("parcon: undefined action %q in PEG grammar", e.Action)
+	}
+	return Convert(p, fn), nil
+}
+
+func (e pegSequence) leftCalls(empty map[string]bool) (rules []string, nullable bool) {
+	for _, x := range e.Items {
+		rs, n := x.leftCalls(empty)
+		rules = append(rules, rs...)
+		if !n {
+			return rules, false
+		}
+	}
+	return rules, true
+}
+
+func (e pegSequence) String() string {
+	ss := make([]string, 0, len(e.Items)+1)
+	for _, x := range e.Items {
+		ss = append(ss, pegParen(x, pegPrecPrefix))
+	}
+	if e.Action != "" {
+		ss = append(ss, "{"+e.Action+"}")
+	}
+	return strings.Join(ss, " ")
+}
+
+type pegPrefix struct {
+	Op   rune
+	Expr pegExpr
+}
+
+func (e pegPrefix) compile(c pegCompiler) (Parser[rune, any], error) {
+	p, err := e.Expr.compile(c)
+	if err != nil {
+		return nil, err
+	}
+
+	switch e.Op {
+	case '&':
+		return Replace[rune, any, any](Peek(p), nil), nil
+	case '!':
+		return Replace[rune, struct{}, any](Not(p), nil), nil
+	default: // '$'
+		return Convert(Convert(MatchOnly(p), ToString), toAny[string]), nil
+	}
+}
+
+func (e pegPrefix) leftCalls(empty map[string]bool) (rules []string, nullable bool) {
+	rules, nullable = e.Expr.leftCalls(empty)
+	// Lookaheads never consume input.
+	return rules, nullable || e.Op != '$'
+}
+
+func (e pegPrefix) String() string {
+	return string(e.Op) + pegParen(e.Expr, pegPrecSuffix)
+}
+
+type pegSuffix struct {
+	Op   rune
+	Expr pegExpr
+}
+
+func (e pegSuffix) compile(c pegCompiler) (Parser[rune, any], error) {
+	p, err := e.Expr.compile(c)
+	if err != nil {
+		return nil, err
+	}
+
+	switch e.Op {
+	case '?':
+		return Optional(p), nil
+	case '*':
+		return Convert(Many(0, p), toAny[[]any]), nil
+	default: // '+'
+		return Convert(Many(1, p), toAny[[]any]), nil
+	}
+}
+
+func (e pegSuffix) leftCalls(empty map[string]bool) (rules []string, nullable bool) {
+	rules, nullable = e.Expr.leftCalls(empty)
+	return rules, nullable || e.Op != '+'
+}
+
+func (e pegSuffix) String() string {
+	return pegParen(e.Expr, pegPrecPrimary) + string(e.Op)
+}
+
+type pegRuleRef string
+
+func (e pegRuleRef) compile(c pegCompiler) (Parser[rune, any], error) {
+	r, ok := c.rules[string(e)]
+	if !ok {
+		return nil, fmt.Errorf("parcon: undefined rule %q in PEG grammar", string(e))
+	}
+	return r, nil
+}
+
+func (e pegRuleRef) leftCalls(empty map[string]bool) (rules []string, nullable bool) {
+	return []string{string(e)}, empty[string(e)]
+}
+
+func (e pegRuleRef) String() string {
+	return string(e)
+}
+
+type pegLiteral string
+
+func (e pegLiteral) compile(c pegCompiler) (Parser[rune, any], error) {
+	return Convert(TagStr(e.String(), string(e)), toAny[string]), nil
+}
+
+func (e pegLiteral) leftCalls(empty map[string]bool) (rules []string, nullable bool) {
+	return nil, len(e) == 0
+}
+
+func (e pegLiteral) String() string {
+	return pegQuote(string(e))
+}
+
+type pegClass struct {
+	Negate bool
+	Ranges [][2]rune
+}
+
+func (e pegClass) compile(c pegCompiler) (Parser[rune, any], error) {
+	var chars []rune
+	for _, r := range e.Ranges {
+		if r[0] != r[1] {
+			chars = nil
+			break
+		}
+		chars = append(chars, r[0])
+	}
+
+	var p Parser[rune, rune]
+	switch {
+	case chars != nil && e.Negate:
+		p = NoneOf(e.String(), chars)
+	case chars != nil:
+		p = OneOf(e.String(), chars)
+	default:
+		p = TakeSingle(e.String(), e.match)
+	}
+	return Convert(p, runeToAny), nil
+}
+
+func (e pegClass) leftCalls(empty map[string]bool) (rules []string, nullable bool) {
+	return nil, false
+}
+
+// match checks if `c` is matched to the class.
+func (e pegClass) match(c rune) bool {
+	for _, r := range e.Ranges {
+		if r[0] <= c && c <= r[1] {
+			return !e.Negate
+		}
+	}
+	return e.Negate
+}
+
+// String returns the class in PEG notation like "[a-z_]".
+func (e pegClass) String() string {
+	var b strings.Builder
+	b.WriteByte('[')
+	if e.Negate {
+		b.WriteByte('^')
+	}
+	for _, r := range e.Ranges {
+		b.WriteString(pegEscape(r[0], pegClassSpecials))
+		if r[0] != r[1] {
+			b.WriteByte('-')
+			b.WriteString(pegEscape(r[1], pegClassSpecials))
+		}
+	}
+	b.WriteByte(']')
+	return b.String()
+}
+
+type pegAny struct{}
+
+func (e pegAny) compile(c pegCompiler) (Parser[rune, any], error) {
+	return Convert(Anything[rune](), runeToAny), nil
+}
+
+func (e pegAny) leftCalls(empty map[string]bool) (rules []string, nullable bool) {
+	return nil, false
+}
+
+func (e pegAny) String() string {
+	return "."
+}
+
+func toAny[T any](x T) (any, error) {
+	return x, nil
+}
+
+func runeToAny(c rune) (any, error) {
+	return string(c), nil
+}
+
+// pegClassSpecials is the characters that have to be escaped in a class of PEG.
+const pegClassSpecials = `[]-^`
+
+// pegQuote quotes `s` as a literal of PEG.
+func pegQuote(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, c := range s {
+		b.WriteString(pegEscape(c, `"`))
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// pegEscape escapes a character in a literal or a class, with only the escapes that CompilePEG accepts.
+// The backslash and characters in `specials` are escaped by a backslash.
+//
+// It does not use strconv.Quote, because escapes of Go like "\x01" or "\a" are not valid in PEG.
+func pegEscape(c rune, specials string) string {
+	switch {
+	case c == '\n':
+		return `\n`
+	case c == '\r':
+		return `\r`
+	case c == '\t':
+		return `\t`
+	case c == '\\' || strings.ContainsRune(specials, c):
+		return `\` + string(c)
+	case unicode.IsPrint(c) || c > 0xFFFF:
+		// PEG has only 4 digits escape, so characters out of BMP are written as is.
+		return string(c)
+	default:
+		return fmt.Sprintf(`\u%04X`, c)
+	}
+}
+
+// pegUnescape converts the character after backslash into the character that it means.
+func pegUnescape(c rune) (rune, error) {
+	switch c {
+	case 'n':
+		return '\n', nil
+	case 'r':
+		return '\r', nil
+	case 't':
+		return '\t', nil
+	default:
+		return c, nil
+	}
+}
+
+func isPEGIdentStart(c rune) bool {
+	return c == '_' || isAlpha(c)
+}
+
+func isPEGIdentChar(c rune) bool {
+	return c == '_' || isAlphaNum(c)
+}
+
+// pegGrammar is the parser of PEG grammars for CompilePEG.
+var pegGrammar = Lazy(func() Parser[rune, []pegDefinition] {
+	comment := WithPrefix(TagStr("COMMENT", "#"), Optional(NoneOfList("CHARACTER", []rune("\r\n"))))
+	spacing := Named("SPACE", Many(0, Or(MultiSpacesOrNewlines, comment)))
+	token := func(name, s string) Parser[rune, string] {
+		return WithSuffix(TagStr(name, s), spacing)
+	}
+
+	identifier := Named("IDENTIFIER", WithSuffix(
+		Convert(MatchOnly(Pair(TakeSingle("IDENTIFIER", isPEGIdentStart), Optional(TakeWhile("IDENTIFIER", isPEGIdentChar)))), ToString),
+		spacing,
+	))
+	arrow := token("ARROW", "<-")
+
+	escape := WithPrefix(TagStr("BACKSLASH", `\`), Or(
+		Convert(WithPrefix(TagStr("UNICODE", "u"), MatchOnly(Repeat(4, SingleHexDigit))), func(hex []rune) (rune, error) {
+			n, err := strconv.ParseUint(string(hex), 16, 32)
+			return rune(n), err
+		}),
+		Convert(OneOf("ESCAPE", []rune(`nrt\'"[]-^`)), pegUnescape),
+	))
+	char := func(end rune) Parser[rune, rune] {
+		return Or(escape, NoneOf("CHARACTER", []rune{'\\', end, '\r', '\n'}))
+	}
+	quoted := func(quote string) Parser[rune, pegExpr] {
+		q := TagStr("QUOTE", quote)
+		return Convert(WithEnclosure(q, Many(0, char([]rune(quote)[0])), q), func(cs []rune) (pegExpr, error) {
+			return pegLiteral(cs), nil
+		})
+	}
+	literal := WithSuffix(Or(quoted(`"`), quoted(`'`)), spacing)
+
+	classRange := Or(
+		Convert(Pair(char(']'), WithPrefix(TagStr("HYPHEN", "-"), char(']'))), func(p PairValue[rune, rune]) ([2]rune, error) {
+			return [2]rune{p.First, p.Second}, nil
+		}),
+		Convert(char(']'), func(c rune) ([2]rune, error) {
+			return [2]rune{c, c}, nil
+		}),
+	)
+	class := WithSuffix(Convert(
+		Pair(
+			WithPrefix(TagStr("BEGIN_CLASS", "["), OptionalWithDefault(Replace(TagStr("CARET", "^"), true), false)),
+			WithSuffix(Many(0, classRange), TagStr("END_CLASS", "]")),
+		),
+		func(p PairValue[bool, [][2]rune]) (pegExpr, error) {
+			return pegClass{p.First, p.Second}, nil
+		},
+	), spacing)
+
+	var expression Ref[rune, pegExpr]
+
+	primary := Or(
+		Convert(WithSuffix(identifier, Not(arrow)), func(name string) (pegExpr, error) {
+			return pegRuleRef(name), nil
+		}),
+		WithEnclosure(token("OPEN", "("), Parser[rune, pegExpr](&expression), token("CLOSE", ")")),
+		literal,
+		class,
+		Replace[rune, string, pegExpr](token("DOT", "."), pegAny{}),
+	)
+
+	suffix := Convert(Pair(primary, Optional(WithSuffix(OneOf("SUFFIX", []rune("?*+")), spacing))), func(p PairValue[pegExpr, rune]) (pegExpr, error) {
+		if p.Second == 0 {
+			return p.First, nil
+		}
+		return pegSuffix{p.Second, p.First}, nil
+	})
+
+	prefix := Convert(Pair(Optional(WithSuffix(OneOf("PREFIX", []rune("&!$")), spacing)), suffix), func(p PairValue[rune, pegExpr]) (pegExpr, error) {
+		if p.First == 0 {
+			return p.Second, nil
+		}
+		return pegPrefix{p.First, p.Second}, nil
+	})
+
+	action := WithEnclosure(token("BEGIN_ACTION", "{"), identifier, token("END_ACTION", "}"))
+
+	sequence := Convert(Pair(Many(0, prefix), Optional(action)), func(p PairValue[[]pegExpr, string]) (pegExpr, error) {
+		if len(p.First) == 1 && p.Second == "" {
+			return p.First[0], nil
+		}
+		return pegSequence{p.First, p.Second}, nil
+	})
+
+	expression.Set(Convert(SeparatedList(1, token("SLASH", "/"), sequence), func(es []pegExpr) (pegExpr, error) {
+		if len(es) == 1 {
+			return es[0], nil
+		}
+		return pegChoice(es), nil
+	}))
+
+	// A rule ends at the end of input or at the beginning of the next rule.
+	end := Or(
+		Replace(EOF[rune](), ""),
+		Named("RULE", Peek(WithSuffix(identifier, arrow))),
+	)
+
+	definition := Convert(
+		Pair(identifier, WithPrefix(arrow, WithSuffix(Parser[rune, pegExpr](&expression), Commit(end)))),
+		func(p PairValue[string, pegExpr]) (pegDefinition, error) {
+			return pegDefinition{p.First, p.Second}, nil
+		},
+	)
+
+	return WithPrefix(spacing, Many(1, definition))
+})
@@ -0,0 +1,132 @@
+package parcon_test
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/macrat/parcon"
+)
+
+func ExampleCompilePEG() {
+	parser, err := parcon.CompilePEG(`
+		# A sum of numbers like "1 + 2 + 3".
+		sum    <- number ("+" number)* {sum}
+		number <- _ $[0-9]+ _          {number}
+		_      <- [ \t]*
+	`, map[string]parcon.ConvertFunc[any, any]{
+		"sum": func(x any) (any, error) {
+			xs := x.([]any)
+			n := xs[0].(int)
+			for _, y := range xs[1].([]any) {
+				n += y.([]any)[1].(int)
+			}
+			return n, nil
+		},
+		"number": func(x any) (any, error) {
+			return strconv.Atoi(x.([]any)[1].(string))
+		},
+	})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(parcon.ParseString(parser, "1 + 20 + 300"))
+	fmt.Println(parcon.ParseString(parser, "1 + x", parcon.Verbose(true)))
+
+	// OUTPUT:
+	// 321 <nil>
+	// <nil> invalid input at 1:5: expected [ \t] or [0-9] but got "x"
+}
+
+func ExampleCompilePEG_outputs() {
+	parser, err := parcon.CompilePEG(`
+		list  <- "[" items? "]"
+		items <- item ("," item)*
+		item  <- $(!["\],] .)+ / '"' (!'"' .)* '"'
+	`, nil)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	output, err := parcon.ParseString(parser, `[ab,"c"]`)
+	fmt.Printf("%#v %v\n", output, err)
+
+	// OUTPUT:
+	// []interface {}{"[", []interface {}{"ab", []interface {}{[]interface {}{",", []interface {}{"\"", []interface {}{[]interface {}{interface {}(nil), "c"}}, "\""}}}}, "]"} <nil>
+}
+
+func ExampleCompilePEG_errors() {
+	_, err := parcon.CompilePEG(`greeting <- "hello" (world`, nil)
+	fmt.Println(err)
+
+	_, err = parcon.CompilePEG(`greeting <- "hello" world`, nil)
+	fmt.Println(err)
+
+	_, err = parcon.CompilePEG(`greeting <- "hello" {upper}`, nil)
+	fmt.Println(err)
+
+	_, err = parcon.CompilePEG(`a <- a "x" / "x"`, nil)
+	fmt.Println(err)
+
+	_, err = parcon.CompilePEG(`
+		a <- b "x" / "x"
+		b <- _ c
+		c <- "y"? a
+		_ <- " "*
+	`, nil)
+	fmt.Println(err)
+
+	// OUTPUT:
+	// parcon: invalid PEG grammar: invalid input at 1:27: expected SPACE, SUFFIX, PREFIX, IDENTIFIER, OPEN, QUOTE, BEGIN_CLASS, DOT, BEGIN_ACTION, SLASH, or CLOSE but got end of input
+	// parcon: undefined rule "world" in PEG grammar
+	// parcon: undefined action "upper" in PEG grammar
+	// parcon: left recursive rule "a" in PEG grammar: a -> a
+	// parcon: left recursive rule "a" in PEG grammar: a -> b -> c -> a
+}
+
+func ExampleCompilePEG_export() {
+	parser, err := parcon.CompilePEG(`
+		greeting <- word (", " word)*
+		word     <- "hello" / "world"
+	`, nil)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Print(parcon.PEG(parser))
+
+	// OUTPUT:
+	// greeting <- word (", " word)*
+	// word <- "hello" / "world"
+}
+
+func Test_pegRoundTrip(t *testing.T) {
+	grammar := `
+		a <- "x\u0001\u007F\"\\" [\u0000\u0002\u001F\]] b
+		b <- [^\u0007\t-]
+	`
+	parser, err := parcon.CompilePEG(grammar, nil)
+	if err != nil {
+		t.Fatalf("failed to compile the original grammar: %v", err)
+	}
+	exported := parcon.PEG(parser)
+
+	again, err := parcon.CompilePEG(exported, nil)
+	if err != nil {
+		t.Fatalf("failed to compile the exported grammar: %v\n%s", err, exported)
+	}
+	if s := parcon.PEG(again); s != exported {
+		t.Errorf("the exported grammar changed after round trip:\n%s\n%s", exported, s)
+	}
+
+	if _, err := parcon.ParseString(again, "x\x01\x7f\"\\\x02a"); err != nil {
+		t.Errorf("failed to parse with the exported grammar: %v", err)
+	}
+	if _, err := parcon.ParseString(again, "x\x01\x7f\"\\\x02\a"); err == nil {
+		t.Errorf("expected an error of the negated class")
+	}
+}
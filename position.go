@@ -0,0 +1,164 @@
+package parcon
+
+import (
+	"fmt"
+	"sort"
+	"unicode/utf8"
+)
+
+// Position is a position in the input.
+// If the input is []Token that made by Lexer, it is the position in the input of Lexer.
+type Position struct {
+	// Offset is 0-origin index in the input.
+	Offset int
+
+	// Line is 1-origin line number.
+	// It is 0 if the input is not text.
+	// []rune is always treated as text, and []byte is treated as UTF-8 encoded text only if UTF8Text is set, like ParseString does.
+	Line int
+
+	// Column is 1-origin column number in the line.
+	// It is 0 if the input is not text, as the same as Line.
+	Column int
+}
+
+// String returns human readable string like "line:column".
+func (p Position) String() string {
+	if p.Line == 0 {
+		return fmt.Sprintf("offset %d", p.Offset)
+	}
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+// join returns the absolute position of `rel` that is a position relative to `p`.
+func (p Position) join(rel Position) Position {
+	if p.Line == 0 {
+		return Position{Offset: p.Offset + rel.Offset}
+	}
+	if rel.Line == 1 {
+		return Position{p.Offset + rel.Offset, p.Line, p.Column + rel.Column - 1}
+	}
+	return Position{p.Offset + rel.Offset, p.Line + rel.Line - 1, rel.Column}
+}
+
+// PositionOf returns the position of `remain` in the `input`.
+//
+// The `remain` has to be a part of the `input`, like the `remain` that returned by Parser.
+// If you parse many times with the same input, Session is faster than this function.
+// []byte is not treated as text by this function. Please use Session with UTF8Text if you want the line and column of []byte.
+func PositionOf[I comparable](input, remain []I) Position {
+	offset := len(input) - len(remain)
+
+	return positionInLines(input, lineStarts(input[:offset], false), offset)
+}
+
+// lineStarts returns offsets of the beginning of each lines.
+// It returns nil if the input is not text. Text is []rune, or []byte if `utf8Text` is true.
+func lineStarts[I comparable](input []I, utf8Text bool) []int {
+	switch xs := any(input).(type) {
+	case []rune:
+		lines := []int{0}
+		for i, c := range xs {
+			if c == '\n' {
+				lines = append(lines, i+1)
+			}
+		}
+		return lines
+	case []byte:
+		if !utf8Text {
+			return nil
+		}
+		lines := []int{0}
+		for i, c := range xs {
+			if c == '\n' {
+				lines = append(lines, i+1)
+			}
+		}
+		return lines
+	default:
+		return nil
+	}
+}
+
+// positionInLines makes a Position of `offset` in the `input` using the result of lineStarts.
+//
+// The column is counted in characters even if the input is []byte, but the offset is counted in bytes.
+// If the input is []Token, it returns the position in the input of Lexer.
+func positionInLines[I comparable](input []I, lines []int, offset int) Position {
+	if tokens, ok := any(input).([]Token); ok {
+		return tokenPosition(tokens, offset)
+	}
+	if lines == nil {
+		return Position{Offset: offset}
+	}
+
+	line := sort.Search(len(lines), func(i int) bool {
+		return lines[i] > offset
+	})
+
+	column := offset - lines[line-1] + 1
+	if xs, ok := any(input).([]byte); ok {
+		column = utf8.RuneCount(xs[lines[line-1]:offset]) + 1
+	}
+
+	return Position{
+		Offset: offset,
+		Line:   line,
+		Column: column,
+	}
+}
+
+// Span is a range in the input.
+type Span struct {
+	Start Position
+	End   Position
+}
+
+// String returns human readable string like "line:column-line:column".
+func (s Span) String() string {
+	return fmt.Sprintf("%v-%v", s.Start, s.End)
+}
+
+// Spanned is a value with the Span where it is parsed from.
+type Spanned[O any] struct {
+	Value O
+	Span  Span
+}
+
+type spanParser[I comparable, O any] struct {
+	Parser Parser[I, O]
+}
+
+// WithSpan parses input using the given `parser`, and returns the output with its Span.
+//
+// The positions are offsets in the input of Session if the parser is used in a Session.
+// Otherwise, they are relative to the input of this parser.
+func WithSpan[I comparable, O any](parser Parser[I, O]) Parser[I, Spanned[O]] {
+	return spanParser[I, O]{parser}
+}
+
+func (s spanParser[I, O]) Parse(input []I, verbose bool) (output Spanned[O], remain []I, err error) {
+	output.Value, remain, err = s.Parser.Parse(input, verbose)
+	if err != nil {
+		return
+	}
+
+	if sess := sessionOf(input); sess != nil {
+		output.Span = Span{sess.Position(input), sess.Position(remain)}
+	} else {
+		output.Span = Span{PositionOf(input, input), PositionOf(input, remain)}
+	}
+	return
+}
+
+func (s spanParser[I, O]) String() string {
+	return fmt.Sprint(s.Parser)
+}
+
+func (s spanParser[I, O]) Kind() Kind {
+	return KindWithSpan
+}
+
+func (s spanParser[I, O]) Children() []Node {
+	return nodesOf(s.Parser)
+}
@@ -0,0 +1,41 @@
+package parcon_test
+
+import (
+	"fmt"
+
+	"github.com/macrat/parcon"
+)
+
+func ExamplePositionOf() {
+	input := []rune("hello\nworld")
+
+	_, remain, _ := parcon.TagStr("HELLO", "hello\nwo").Parse(input, true)
+	fmt.Println(parcon.PositionOf(input, remain))
+
+	// OUTPUT:
+	// 2:3
+}
+
+func ExampleWithSpan() {
+	parser := parcon.SeparatedList(
+		0,
+		parcon.SingleNewline,
+		parcon.WithSpan(parcon.Convert(parcon.MultiAlphas, parcon.ToString)),
+	)
+
+	session := parcon.NewSession([]rune("hello\nworld"))
+	defer session.Close()
+
+	output, _, err := parser.Parse(session.Input(), true)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, x := range output {
+		fmt.Printf("%s: %s (offset %d-%d)\n", x.Value, x.Span, x.Span.Start.Offset, x.Span.End.Offset)
+	}
+
+	// OUTPUT:
+	// hello: 1:1-1:6 (offset 0-5)
+	// world: 2:1-2:6 (offset 6-11)
+}
@@ -0,0 +1,431 @@
+package parcon
+
+import (
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// RailroadDiagram is a railroad diagram, also known as a syntax diagram, of a rule.
+type RailroadDiagram struct {
+	// Name is the name of the rule.
+	Name string
+
+	// SVG is the diagram as a standalone SVG document.
+	SVG string
+}
+
+// Railroad makes railroad diagrams of `parser`.
+//
+// Each Named parser becomes a diagram, and the first diagram is the `parser` itself, as the same as EBNF.
+// Boxes for other rules have links like "#rule-NAME", that work in the HTML made by RailroadHTML.
+func Railroad(parser any) []RailroadDiagram {
+	r := newRailroadWriter()
+	r.rules.start(NodeOf(parser))
+
+	var ds []RailroadDiagram
+	r.rules.each(r.item, func(name string, body railroadItem) {
+		ds = append(ds, RailroadDiagram{name, railroadSVG(body)})
+	})
+	return ds
+}
+
+// RailroadHTML makes a standalone HTML document that shows railroad diagrams of `parser`.
+func RailroadHTML(parser any) string {
+	var b strings.Builder
+
+	b.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>Grammar</title>\n")
+	b.WriteString("<style>body { font-family: sans-serif; } h2 { font-size: 16px; }</style>\n")
+	b.WriteString("</head>\n<body>\n")
+
+	for _, d := range Railroad(parser) {
+		fmt.Fprintf(&b, "<section id=\"rule-%s\">\n<h2>%s</h2>\n%s</section>\n", d.Name, html.EscapeString(d.Name), d.SVG)
+	}
+
+	b.WriteString("</body>\n</html>\n")
+	return b.String()
+}
+
+// Sizes of railroad diagrams in pixels.
+const (
+	railroadCharWidth  = 8
+	railroadBoxPadding = 10
+	railroadBoxHeight  = 24
+	railroadGap        = 10
+	railroadRadius     = 10
+	railroadMargin     = 20
+)
+
+// railroadItem is an element of railroad diagram.
+//
+// An item is drawn from (x, y) to (x + width, y), and it uses the space from y - up to y + down.
+type railroadItem interface {
+	size() (width, up, down int)
+	draw(b *strings.Builder, x, y int)
+}
+
+// railroadBox is a box that has a text, like a terminal or a reference to another rule.
+type railroadBox struct {
+	Text  string
+	Class string
+	Link  string
+}
+
+func (r railroadBox) size() (width, up, down int) {
+	return utf8.RuneCountInString(r.Text)*railroadCharWidth + 2*railroadBoxPadding, railroadBoxHeight / 2, railroadBoxHeight / 2
+}
+
+func (r railroadBox) draw(b *strings.Builder, x, y int) {
+	w, _, _ := r.size()
+	rx := 0
+	if r.Class == "terminal" {
+		rx = railroadBoxHeight / 2
+	}
+
+	if r.Link != "" {
+		fmt.Fprintf(b, `<a href="%s">`, html.EscapeString(r.Link))
+	}
+	fmt.Fprintf(b, `<g class="%s"><rect x="%d" y="%d" width="%d" height="%d" rx="%d"/>`, r.Class, x, y-railroadBoxHeight/2, w, railroadBoxHeight, rx)
+	fmt.Fprintf(b, `<text x="%d" y="%d">%s</text></g>`, x+w/2, y+4, html.EscapeString(r.Text))
+	if r.Link != "" {
+		b.WriteString(`</a>`)
+	}
+	b.WriteString("\n")
+}
+
+// railroadSkip is an empty item.
+type railroadSkip struct{}
+
+func (r railroadSkip) size() (width, up, down int) {
+	return 0, 0, 0
+}
+
+func (r railroadSkip) draw(b *strings.Builder, x, y int) {}
+
+type railroadSequence []railroadItem
+
+func (r railroadSequence) size() (width, up, down int) {
+	for i, item := range r {
+		w, u, d := item.size()
+		if i > 0 {
+			width += railroadGap
+		}
+		width += w
+		up = maxInt(up, u)
+		down = maxInt(down, d)
+	}
+	return
+}
+
+func (r railroadSequence) draw(b *strings.Builder, x, y int) {
+	for i, item := range r {
+		if i > 0 {
+			railroadLine(b, x, x+railroadGap, y)
+			x += railroadGap
+		}
+		item.draw(b, x, y)
+		w, _, _ := item.size()
+		x += w
+	}
+}
+
+// railroadChoice is a choice of items. The first item is on the main line, and others are below it.
+type railroadChoice []railroadItem
+
+// offsets returns the vertical offsets of items from the main line.
+func (r railroadChoice) offsets() []int {
+	offsets := make([]int, len(r))
+	_, _, bottom := r[0].size()
+	for i := 1; i < len(r); i++ {
+		_, u, d := r[i].size()
+		offsets[i] = maxInt(bottom+railroadGap+u, 2*railroadRadius)
+		bottom = offsets[i] + d
+	}
+	return offsets
+}
+
+func (r railroadChoice) size() (width, up, down int) {
+	for _, item := range r {
+		w, _, _ := item.size()
+		width = maxInt(width, w)
+	}
+	offsets := r.offsets()
+	_, up, down = r[0].size()
+	_, _, d := r[len(r)-1].size()
+	return width + 4*railroadRadius, up, maxInt(down, offsets[len(r)-1]+d)
+}
+
+func (r railroadChoice) draw(b *strings.Builder, x, y int) {
+	width, _, _ := r.size()
+	offsets := r.offsets()
+	rad := railroadRadius
+
+	for i, item := range r {
+		w, _, _ := item.size()
+		iy := y + offsets[i]
+
+		if i == 0 {
+			railroadLine(b, x, x+2*rad, y)
+		} else {
+			fmt.Fprintf(b, `<path d="M%d %d a%d %d 0 0 1 %d %d v%d a%d %d 0 0 0 %d %d"/>`+"\n", x, y, rad, rad, rad, rad, offsets[i]-2*rad, rad, rad, rad, rad)
+		}
+
+		item.draw(b, x+2*rad, iy)
+		railroadLine(b, x+2*rad+w, x+width-2*rad, iy)
+
+		if i == 0 {
+			railroadLine(b, x+width-2*rad, x+width, y)
+		} else {
+			fmt.Fprintf(b, `<path d="M%d %d a%d %d 0 0 0 %d %d v%d a%d %d 0 0 1 %d %d"/>`+"\n", x+width-2*rad, iy, rad, rad, rad, -rad, -(offsets[i] - 2*rad), rad, rad, rad, -rad)
+		}
+	}
+}
+
+// railroadLoop is a loop that repeats Body one or more times, with Repeat between them.
+type railroadLoop struct {
+	Body   railroadItem
+	Repeat railroadItem
+	Label  string
+}
+
+// offset returns the vertical offset of the Repeat from the main line.
+func (r railroadLoop) offset() int {
+	_, _, bd := r.Body.size()
+	_, ru, _ := r.Repeat.size()
+	return maxInt(bd+railroadGap+ru, 2*railroadRadius)
+}
+
+func (r railroadLoop) size() (width, up, down int) {
+	bw, bu, _ := r.Body.size()
+	rw, _, rd := r.Repeat.size()
+	down = r.offset() + rd
+	if r.Label != "" {
+		down += railroadBoxHeight / 2
+	}
+	return maxInt(bw, rw) + 2*railroadRadius, bu, down
+}
+
+func (r railroadLoop) draw(b *strings.Builder, x, y int) {
+	width, _, _ := r.size()
+	bw, _, _ := r.Body.size()
+	rw, _, rd := r.Repeat.size()
+	ly := y + r.offset()
+	rad := railroadRadius
+
+	railroadLine(b, x, x+rad, y)
+	r.Body.draw(b, x+rad, y)
+	railroadLine(b, x+rad+bw, x+width, y)
+
+	fmt.Fprintf(b, `<path d="M%d %d a%d %d 0 0 1 %d %d v%d a%d %d 0 0 1 %d %d"/>`+"\n", x+width-rad, y, rad, rad, rad, rad, ly-y-2*rad, rad, rad, -rad, rad)
+	railroadLine(b, x+width-rad, x+rad+rw, ly)
+	r.Repeat.draw(b, x+rad, ly)
+	fmt.Fprintf(b, `<path d="M%d %d a%d %d 0 0 1 %d %d v%d a%d %d 0 0 1 %d %d"/>`+"\n", x+rad, ly, rad, rad, -rad, -rad, -(ly - y - 2*rad), rad, rad, rad, -rad)
+
+	if r.Label != "" {
+		fmt.Fprintf(b, `<text class="label" x="%d" y="%d">%s</text>`+"\n", x+width/2, ly+rd+railroadBoxHeight/2, html.EscapeString(r.Label))
+	}
+}
+
+// railroadLine draws a horizontal line from (x1, y) to (x2, y).
+func railroadLine(b *strings.Builder, x1, x2, y int) {
+	if x1 != x2 {
+		fmt.Fprintf(b, `<path d="M%d %d H%d"/>`+"\n", x1, y, x2)
+	}
+}
+
+// railroadSVG makes a standalone SVG document of `item`.
+func railroadSVG(item railroadItem) string {
+	w, up, down := item.size()
+	width := w + 2*railroadMargin + 2*railroadGap
+	height := up + down + 2*railroadMargin
+	x, y := railroadMargin, railroadMargin+up
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" class="railroad" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n", width, height, width, height)
+	b.WriteString(`<style>` +
+		`.railroad path { fill: none; stroke: #333; stroke-width: 2; } ` +
+		`.railroad rect { fill: #eef; stroke: #333; stroke-width: 2; } ` +
+		`.railroad .terminal rect { fill: #efe; } ` +
+		`.railroad .special rect { fill: #fff; stroke-dasharray: 4 2; } ` +
+		`.railroad text { font-family: monospace; font-size: 13px; text-anchor: middle; } ` +
+		`.railroad text.label { font-size: 11px; fill: #666; }` +
+		`</style>` + "\n")
+
+	// The start and the end of the rule.
+	fmt.Fprintf(&b, `<path d="M%d %d v-10 m0 20 v-10 H%d"/>`+"\n", x, y, x+railroadGap)
+	item.draw(&b, x+railroadGap, y)
+	fmt.Fprintf(&b, `<path d="M%d %d H%d v-10 m0 20 v-10"/>`+"\n", x+railroadGap+w, y, x+2*railroadGap+w)
+
+	b.WriteString("</svg>\n")
+	return b.String()
+}
+
+type railroadWriter struct {
+	rules *ruleSet[railroadItem]
+}
+
+func newRailroadWriter() *railroadWriter {
+	return &railroadWriter{newRuleSet[railroadItem]()}
+}
+
+// reference returns a box that refers to the rule `name`.
+func (r *railroadWriter) reference(name string) railroadItem {
+	return railroadBox{Text: name, Class: "nonterminal", Link: "#rule-" + name}
+}
+
+func (r *railroadWriter) item(node Node) railroadItem {
+	return r.rules.convert(node, r.nodeItem, r.reference)
+}
+
+func (r *railroadWriter) items(nodes []Node) []railroadItem {
+	items := make([]railroadItem, len(nodes))
+	for i, n := range nodes {
+		items[i] = r.item(n)
+	}
+	return items
+}
+
+func (r *railroadWriter) nodeItem(node Node) railroadItem {
+	children := node.Children()
+
+	switch node.Kind() {
+	case KindNamed:
+		return r.reference(r.rules.named(node))
+	case KindTag:
+		if s, ok := literalString(node.(LiteralNode).Literal()); ok {
+			if strings.IndexFunc(s, func(c rune) bool { return !unicode.IsPrint(c) }) >= 0 {
+				s = strconv.Quote(s)
+			}
+			return railroadBox{Text: s, Class: "terminal"}
+		}
+		return railroadBox{Text: node.String(), Class: "special"}
+	case KindOneOf, KindOneOfList, KindNoneOf, KindNoneOfList:
+		list, ok := literalString(node.(LiteralNode).Literal())
+		if !ok {
+			return railroadBox{Text: node.String(), Class: "special"}
+		}
+		var item railroadItem
+		if node.Kind() == KindOneOf || node.Kind() == KindOneOfList {
+			item = railroadBox{Text: newGrammarWriter(true).set(list).text, Class: "terminal"}
+		} else {
+			item = railroadBox{Text: "any except " + newGrammarWriter(true).set(list).text, Class: "special"}
+		}
+		if node.Kind() == KindOneOfList || node.Kind() == KindNoneOfList {
+			return railroadLoop{item, railroadSkip{}, ""}
+		}
+		return item
+	case KindAnything:
+		return railroadBox{Text: "any", Class: "special"}
+	case KindNothing:
+		return railroadSkip{}
+	case KindEOF:
+		return railroadBox{Text: "end of input", Class: "special"}
+	case KindToken:
+		if t, ok := node.(tokenParser); ok && t.MatchText {
+			return railroadBox{Text: t.Text, Class: "terminal"}
+		}
+		return railroadBox{Text: node.String(), Class: "terminal"}
+	case KindTakeSingle, KindCustom:
+		return railroadBox{Text: node.String(), Class: "special"}
+	case KindTakeWhile:
+		return railroadLoop{railroadBox{Text: node.String(), Class: "special"}, railroadSkip{}, ""}
+	case KindOptional:
+		return railroadOptional(r.item(children[0]))
+	case KindOr:
+		if len(children) == 0 {
+			return railroadSkip{}
+		}
+		return railroadChoice(r.items(children))
+	case KindSequence, KindPair, KindWithEnclosure, KindMatchOnly:
+		return railroadSequence(r.items(children))
+	case KindMany, KindSeparatedList:
+		min, max := node.(RepeatNode).Bounds()
+		body := r.item(children[0])
+		if max == 1 {
+			if min == 0 {
+				return railroadOptional(body)
+			}
+			return body
+		}
+
+		var repeat railroadItem = railroadSkip{}
+		if len(children) > 1 {
+			repeat = r.item(children[1])
+		}
+		loop := railroadLoop{body, repeat, railroadLabel(min, max)}
+		if min == 0 {
+			return railroadOptional(loop)
+		}
+		return loop
+	case KindAligned:
+		return railroadLoop{r.item(children[0]), railroadSkip{}, ""}
+	case KindIndentBlock:
+		return railroadSequence([]railroadItem{r.item(children[0]), railroadLoop{r.item(children[1]), railroadSkip{}, ""}})
+	case KindPeek, KindNot:
+		text := newGrammarWriter(true).term(children[0]).text
+		if node.Kind() == KindPeek {
+			return railroadBox{Text: "followed by " + text, Class: "special"}
+		}
+		return railroadBox{Text: "not " + text, Class: "special"}
+	case KindExpression:
+		atom, prefix, postfix, infix := node.(expressionNode).operators()
+		operand := railroadSequence{
+			railroadMany(railroadChoice(r.items(prefix))),
+			r.item(atom),
+			railroadMany(railroadChoice(r.items(postfix))),
+		}
+		if len(infix) == 0 {
+			return operand
+		}
+		return railroadLoop{operand, railroadChoice(r.items(infix)), ""}
+	default:
+		// Parsers that do not change the grammar, like Convert or Ref.
+		if len(children) == 0 {
+			return railroadBox{Text: node.String(), Class: "special"}
+		}
+		return r.item(children[0])
+	}
+}
+
+// railroadOptional makes an item that can be skipped.
+func railroadOptional(item railroadItem) railroadItem {
+	return railroadChoice{railroadSkip{}, item}
+}
+
+// railroadMany makes an item that repeats zero or more times.
+// It returns railroadSkip if `choice` is empty.
+func railroadMany(choice railroadChoice) railroadItem {
+	if len(choice) == 0 {
+		return railroadSkip{}
+	}
+	return railroadOptional(railroadLoop{choice, railroadSkip{}, ""})
+}
+
+// railroadLabel makes a label of loop like "2-4 times".
+func railroadLabel(min, max uint) string {
+	if min <= 1 && max == 0 {
+		return ""
+	}
+	if min == 0 {
+		min = 1
+	}
+	switch {
+	case max == 0:
+		return fmt.Sprintf("%d+ times", min)
+	case min == max:
+		return fmt.Sprintf("%d times", min)
+	default:
+		return fmt.Sprintf("%d-%d times", min, max)
+	}
+}
+
+// maxInt returns the larger of `a` and `b`.
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
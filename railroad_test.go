@@ -0,0 +1,67 @@
+package parcon_test
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/macrat/parcon"
+)
+
+func ExampleRailroad() {
+	for _, d := range parcon.Railroad(configParser()) {
+		fmt.Println(d.Name, strings.HasPrefix(d.SVG, "<svg "))
+	}
+
+	// OUTPUT:
+	// CONFIG true
+	// ENTRY true
+	// KEY true
+	// VALUE true
+}
+
+func ExampleRailroad_sameNames() {
+	parser := parcon.Sequence(
+		parcon.Named("ITEM", parcon.TagStr("A", "a")),
+		parcon.Named("ITEM", parcon.TagStr("B", "b")),
+	)
+
+	for _, d := range parcon.Railroad(parser) {
+		fmt.Println(d.Name, strings.Contains(d.SVG, `href="#rule-ITEM_2"`))
+	}
+
+	// OUTPUT:
+	// ROOT true
+	// ITEM false
+	// ITEM_2 false
+}
+
+func ExampleRailroadHTML() {
+	page := parcon.RailroadHTML(configParser())
+
+	fmt.Println(strings.Count(page, "<svg "))
+	fmt.Println(strings.Contains(page, `<section id="rule-ENTRY">`))
+	fmt.Println(strings.Contains(page, `<a href="#rule-ENTRY">`))
+
+	// OUTPUT:
+	// 4
+	// true
+	// true
+}
+
+func Test_railroadWellFormed(t *testing.T) {
+	for _, d := range parcon.Railroad(jsonValue) {
+		decoder := xml.NewDecoder(strings.NewReader(d.SVG))
+		for {
+			_, err := decoder.Token()
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				t.Errorf("%s: invalid SVG: %s", d.Name, err)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package parcon
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ErrorList is a list of errors that recovered by Recover.
+type ErrorList []error
+
+// Error returns messages of all errors, separated by newline.
+func (e ErrorList) Error() string {
+	ss := make([]string, len(e))
+	for i, err := range e {
+		ss[i] = err.Error()
+	}
+	return strings.Join(ss, "\n")
+}
+
+// Unwrap returns all errors in the list.
+func (e ErrorList) Unwrap() []error {
+	return e
+}
+
+type recoverParser[I comparable, O, S any] struct {
+	Parser   Parser[I, O]
+	Sync     Parser[I, S]
+	Fallback O
+}
+
+// Recover parses input using the given `parser`, and recovers from the error if failed.
+//
+// If the `parser` failed, Recover records the error into the Session, skips the input until `sync` matches, and returns `fallback` as the output.
+// The input that matches to `sync` is consumed too. Please use Peek if you want to leave it.
+// For example, you can parse a list that may have broken elements like below.
+//
+//	parcon.SeparatedList(0, comma, parcon.Recover(element, parcon.Peek(parcon.Or(comma, end)), brokenElement))
+//
+// The recorded errors can be got from Session.Errors, or ParseAll reports them as an ErrorList.
+// Recover does not recover if the parser is not used in a Session, or if `sync` never matches.
+func Recover[I comparable, O, S any](parser Parser[I, O], sync Parser[I, S], fallback O) Parser[I, O] {
+	return recoverParser[I, O, S]{parser, sync, fallback}
+}
+
+func (r recoverParser[I, O, S]) Parse(input []I, verbose bool) (output O, remain []I, err error) {
+	if tracing() {
+		defer trace("Recover", r, input).leave(&remain, &err)
+	}
+
+	s := sessionOf(input)
+	cp := s.checkpoint()
+
+	output, remain, err = r.Parser.Parse(input, verbose)
+	if err == nil || s == nil || isAborted(err) {
+		return
+	}
+	s.rollback(cp)
+
+	if !verbose {
+		// Parse again to record the detail of the error.
+		_, _, err = r.Parser.Parse(input, true)
+		s.rollback(cp)
+		if isAborted(err) {
+			return output, input, err
+		}
+	}
+	err = s.explain(err, cp, func() {
+		r.Parser.Parse(input, true)
+	})
+
+	// Skip input from where the error occurred.
+	skip := input
+	e, ok := err.(ErrInvalidInputVerbose[I])
+	if c, committed := err.(ErrCommitted); committed {
+		e, ok = c.Err.(ErrInvalidInputVerbose[I])
+	}
+	if ok && len(e.Input) <= len(input) {
+		skip = input[len(input)-len(e.Input):]
+	}
+
+	// Failures of `sync` are expected while skipping, so they are not the furthest failure.
+	furthest := s.furthest
+	for {
+		var e error
+		_, remain, e = r.Sync.Parse(skip, false)
+		s.furthest = furthest
+		if e == nil {
+			break
+		} else if isAborted(e) {
+			return output, input, e
+		}
+		s.rollback(cp)
+		if len(skip) == 0 {
+			return output, input, err
+		}
+		skip = skip[1:]
+	}
+
+	s.errors = append(s.errors, err)
+	return r.Fallback, remain, nil
+}
+
+func (r recoverParser[I, O, S]) String() string {
+	return fmt.Sprint(r.Parser)
+}
+
+func (r recoverParser[I, O, S]) Kind() Kind {
+	return KindRecover
+}
+
+func (r recoverParser[I, O, S]) Children() []Node {
+	return nodesOf(r.Parser, r.Sync)
+}
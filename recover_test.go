@@ -0,0 +1,57 @@
+package parcon_test
+
+import (
+	"fmt"
+
+	"github.com/macrat/parcon"
+)
+
+func ExampleRecover() {
+	comma := parcon.TagStr("COMMA", ",")
+	end := parcon.Or(parcon.Replace(comma, struct{}{}), parcon.EOF[rune]())
+
+	parser := parcon.SeparatedList(
+		0,
+		comma,
+		parcon.Recover(
+			parcon.WithSuffix(parcon.Convert(parcon.MultiDigits, parcon.ToInt), parcon.Peek(end)),
+			parcon.Peek(end),
+			-1,
+		),
+	)
+
+	output, err := parcon.ParseString(parser, "1,2,x,4,5y,6")
+	fmt.Printf("output:%#v\n", output)
+
+	if errs, ok := err.(parcon.ErrorList); ok {
+		for _, e := range errs {
+			fmt.Println(e)
+		}
+	}
+
+	// OUTPUT:
+	// output:[]int{1, 2, -1, 4, -1, 6}
+	// invalid input at 1:5: expected DIGIT but got "x"
+	// invalid input at 1:10: expected COMMA or EOF but got "y"
+}
+
+func ExampleRecover_lines() {
+	newline := parcon.TagStr("NEWLINE", "\n")
+
+	parser := parcon.Many(
+		0,
+		parcon.Recover(
+			parcon.WithSuffix(parcon.Convert(parcon.MultiDigits, parcon.ToInt), newline),
+			newline,
+			0,
+		),
+	)
+
+	output, err := parcon.ParseString(parser, "10\n2x\n30\n")
+	fmt.Printf("output:%#v\n", output)
+	fmt.Println(err)
+
+	// OUTPUT:
+	// output:[]int{10, 0, 30}
+	// invalid input at 2:2: expected NEWLINE but got "x"
+}
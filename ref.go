@@ -0,0 +1,95 @@
+package parcon
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Ref is a parser that refers another parser that set later.
+// It is useful for recursive grammars.
+// Please wrap a recursive parser with Named, otherwise String of the parser never ends.
+//
+// The zero value of Ref has no parser to refer, so please Set it before parse.
+// Ref panics if parsed before Set.
+type Ref[I comparable, O any] struct {
+	parser Parser[I, O]
+}
+
+// Set sets the parser to refer.
+func (r *Ref[I, O]) Set(parser Parser[I, O]) {
+	r.parser = parser
+}
+
+// Parse parses input using the referred parser.
+func (r *Ref[I, O]) Parse(input []I, verbose bool) (output O, remain []I, err error) {
+	if r.parser == nil {
+		panic("parcon: Ref is parsed before Set")
+	}
+
+	s := sessionOf(input)
+	if err = s.enter(); err != nil {
+		return
+	}
+	defer s.leave()
+
+	return r.parser.Parse(input, verbose)
+}
+
+func (r *Ref[I, O]) String() string {
+	return fmt.Sprint(r.parser)
+}
+
+func (r *Ref[I, O]) Kind() Kind {
+	return KindRef
+}
+
+func (r *Ref[I, O]) Children() []Node {
+	if r.parser == nil {
+		return nil
+	}
+	return nodesOf(r.parser)
+}
+
+type lazyParser[I comparable, O any] struct {
+	once   sync.Once
+	fn     func() Parser[I, O]
+	parser Parser[I, O]
+}
+
+// Lazy makes a parser using `fn` at the first time to parse.
+// It is useful for recursive grammars.
+// Please wrap a recursive parser with Named, otherwise String of the parser never ends.
+//
+// The `fn` is called only once.
+func Lazy[I comparable, O any](fn func() Parser[I, O]) Parser[I, O] {
+	return &lazyParser[I, O]{fn: fn}
+}
+
+func (l *lazyParser[I, O]) get() Parser[I, O] {
+	l.once.Do(func() {
+		l.parser = l.fn()
+	})
+	return l.parser
+}
+
+func (l *lazyParser[I, O]) Parse(input []I, verbose bool) (output O, remain []I, err error) {
+	s := sessionOf(input)
+	if err = s.enter(); err != nil {
+		return
+	}
+	defer s.leave()
+
+	return l.get().Parse(input, verbose)
+}
+
+func (l *lazyParser[I, O]) String() string {
+	return fmt.Sprint(l.get())
+}
+
+func (l *lazyParser[I, O]) Kind() Kind {
+	return KindLazy
+}
+
+func (l *lazyParser[I, O]) Children() []Node {
+	return nodesOf(l.get())
+}
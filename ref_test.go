@@ -0,0 +1,58 @@
+package parcon_test
+
+import (
+	"fmt"
+
+	"github.com/macrat/parcon"
+)
+
+func ExampleRef() {
+	// list := "(" [ item { " " item } ] ")"
+	// item := ALPHAS | list
+	var list parcon.Ref[rune, any]
+
+	item := parcon.Or(
+		parcon.Convert(parcon.MultiAlphas, func(s []rune) (any, error) {
+			return string(s), nil
+		}),
+		parcon.Named("LIST", parcon.Parser[rune, any](&list)),
+	)
+
+	list.Set(parcon.Convert(
+		parcon.WithEnclosure(
+			parcon.TagStr("OPEN", "("),
+			parcon.SeparatedList(0, parcon.SingleSpace, item),
+			parcon.TagStr("CLOSE", ")"),
+		),
+		func(xs []any) (any, error) {
+			return xs, nil
+		},
+	))
+
+	output, remain, err := list.Parse([]rune("(define (add a b) (plus a b))"), true)
+	fmt.Printf("output:%v remain:%#v err:%v\n", output, string(remain), err)
+
+	// OUTPUT:
+	// output:[define [add a b] [plus a b]] remain:"" err:<nil>
+}
+
+func ExampleLazy() {
+	// expr := DIGITS | "(" expr ")"
+	var expr parcon.Parser[rune, int]
+	expr = parcon.Named("EXPR", parcon.Or(
+		parcon.Convert(parcon.MultiDigits, parcon.ToInt),
+		parcon.WithEnclosure(
+			parcon.TagStr("OPEN", "("),
+			parcon.Lazy(func() parcon.Parser[rune, int] {
+				return expr
+			}),
+			parcon.TagStr("CLOSE", ")"),
+		),
+	))
+
+	output, remain, err := expr.Parse([]rune("(((42)))"), true)
+	fmt.Printf("output:%#v remain:%#v err:%v\n", output, string(remain), err)
+
+	// OUTPUT:
+	// output:42 remain:"" err:<nil>
+}
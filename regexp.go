@@ -0,0 +1,82 @@
+package parcon
+
+import (
+	"io"
+	"regexp"
+	"unicode/utf8"
+)
+
+type regexpParser struct {
+	Name   string
+	Regexp *regexp.Regexp
+}
+
+// Regexp parses a sequence that matches to the regular expression `pattern`.
+//
+// The pattern always matches at the beginning of the input, as if it starts with `^`.
+// The syntax is the same as the regexp package, and Regexp panics if the pattern is invalid as the same as regexp.MustCompile.
+// Please note that Regexp succeeds with an empty output if the pattern matches to the empty sequence, like `[0-9]*`.
+func Regexp(name, pattern string) Parser[rune, []rune] {
+	return regexpParser{name, regexp.MustCompile(`^(?:` + pattern + `)`)}
+}
+
+func (r regexpParser) Parse(input []rune, verbose bool) (output []rune, remain []rune, err error) {
+	if tracing() {
+		defer trace("Regexp", r, input).leave(&remain, &err)
+	}
+
+	reader := runeReader{input: input}
+	loc := r.Regexp.FindReaderIndex(&reader)
+	if reader.eof {
+		reachEnd(input)
+	}
+	if loc == nil {
+		err = newError(r.Name, input, verbose)
+		return
+	}
+
+	// The location is in bytes of UTF-8, so convert it into the number of runes.
+	n := 0
+	for size := 0; size < loc[1]; n++ {
+		size += runeSize(input[n])
+	}
+	return input[:n], input[n:], nil
+}
+
+func (r regexpParser) String() string {
+	return r.Name
+}
+
+func (r regexpParser) Kind() Kind {
+	return KindRegexp
+}
+
+func (r regexpParser) Children() []Node {
+	return nil
+}
+
+// runeReader is an io.RuneReader for []rune.
+type runeReader struct {
+	input []rune
+	pos   int
+	eof   bool
+}
+
+func (r *runeReader) ReadRune() (c rune, size int, err error) {
+	if r.pos >= len(r.input) {
+		r.eof = true
+		return 0, 0, io.EOF
+	}
+	c = r.input[r.pos]
+	r.pos++
+	return c, runeSize(c), nil
+}
+
+// runeSize returns the size of `c` in UTF-8.
+// Invalid runes are counted as the size of utf8.RuneError, because they are encoded as it.
+func runeSize(c rune) int {
+	if n := utf8.RuneLen(c); n > 0 {
+		return n
+	}
+	return utf8.RuneLen(utf8.RuneError)
+}
@@ -0,0 +1,20 @@
+package parcon_test
+
+import (
+	"fmt"
+
+	"github.com/macrat/parcon"
+)
+
+func ExampleRegexp() {
+	parser := parcon.Convert(parcon.Regexp("NUMBER", `-?[0-9]+(\.[0-9]+)?`), parcon.ToFloat)
+
+	fmt.Println(parcon.ParseString(parser, "-12.5"))
+	fmt.Println(parcon.ParseString(parser, "-12.", parcon.Verbose(true)))
+	fmt.Println(parcon.ParseString(parser, "abc", parcon.Verbose(true)))
+
+	// OUTPUT:
+	// -12.5 <nil>
+	// 0 invalid input at 1:4: expected EOF but got "."
+	// 0 invalid input at 1:1: expected NUMBER but got "a"
+}
@@ -62,6 +62,10 @@ func Repeat[I comparable, O any](num uint, parser Parser[I, O]) Parser[I, []O] {
 }
 
 func (l listParser[I, O, D]) Parse(input []I, verbose bool) (output []O, remain []I, err error) {
+	if tracing() {
+		defer trace(string(l.Kind()), l, input).leave(&remain, &err)
+	}
+
 	if l.Max == 0 {
 		output = make([]O, 0)
 	} else {
@@ -70,14 +74,25 @@ func (l listParser[I, O, D]) Parse(input []I, verbose bool) (output []O, remain
 
 	var o O
 
+	s := sessionOf(input)
+	cp := s.checkpoint()
+
+	if err = s.step(); err != nil {
+		return
+	}
+
 	o, remain, err = l.Parser.Parse(input, verbose && l.Min != 0)
 	if err != nil {
-		if l.Min == 0 {
+		if l.Min == 0 && !isFatal(err) {
+			s.rollback(cp)
 			err = nil
 			remain = input
 		}
 		return
 	}
+	if err = s.emit(); err != nil {
+		return
+	}
 	output = append(output, o)
 
 	var count uint = 1
@@ -85,16 +100,37 @@ func (l listParser[I, O, D]) Parse(input []I, verbose bool) (output []O, remain
 	for l.Max == 0 || count < l.Max {
 		var r []I
 
+		cp = s.checkpoint()
+
+		if err = s.step(); err != nil {
+			return
+		}
+
 		_, r, err = l.Delimiter.Parse(remain, verbose && count < l.Min)
-		if err != nil {
+		if isFatal(err) {
+			return
+		} else if err != nil {
+			s.rollback(cp)
 			break
 		}
 
 		o, r, err = l.Parser.Parse(r, verbose && count < l.Min)
-		if err != nil {
+		if isFatal(err) {
+			return
+		} else if err != nil {
+			s.rollback(cp)
 			break
 		}
 
+		if l.Max == 0 && len(r) == len(remain) {
+			// Stop if consumed nothing, otherwise it never ends.
+			s.rollback(cp)
+			break
+		}
+
+		if err = s.emit(); err != nil {
+			return
+		}
 		remain = r
 		output = append(output, o)
 		count++
@@ -115,3 +151,21 @@ func (l listParser[I, O, D]) String() string {
 		return fmt.Sprintf("multiple [%v] separated by [%v]", l.Parser, l.Delimiter)
 	}
 }
+
+func (l listParser[I, O, D]) Kind() Kind {
+	if _, ok := any(l.Delimiter).(nothing[I]); ok {
+		return KindMany
+	}
+	return KindSeparatedList
+}
+
+func (l listParser[I, O, D]) Children() []Node {
+	if l.Kind() == KindMany {
+		return nodesOf(l.Parser)
+	}
+	return nodesOf(l.Parser, l.Delimiter)
+}
+
+func (l listParser[I, O, D]) Bounds() (min, max uint) {
+	return l.Min, l.Max
+}
@@ -13,6 +13,10 @@ func Sequence[I comparable, O any](parsers ...Parser[I, O]) Parser[I, []O] {
 }
 
 func (s sequenceParser[I, O]) Parse(input []I, verbose bool) (output []O, remain []I, err error) {
+	if tracing() {
+		defer trace("Sequence", s, input).leave(&remain, &err)
+	}
+
 	remain = input
 	output = make([]O, len(s))
 	for i, p := range s {
@@ -32,6 +36,18 @@ func (s sequenceParser[I, O]) String() string {
 	return fmt.Sprintf("[%s]", strings.Join(ss, ", "))
 }
 
+func (s sequenceParser[I, O]) Kind() Kind {
+	return KindSequence
+}
+
+func (s sequenceParser[I, O]) Children() []Node {
+	ns := make([]Node, len(s))
+	for i, p := range s {
+		ns[i] = NodeOf(p)
+	}
+	return ns
+}
+
 // PairValue is a pair of values.
 type PairValue[F, S any] struct {
 	First  F
@@ -49,6 +65,10 @@ func Pair[I comparable, O1, O2 any](first Parser[I, O1], second Parser[I, O2]) P
 }
 
 func (p pairParser[I, O1, O2]) Parse(input []I, verbose bool) (output PairValue[O1, O2], remain []I, err error) {
+	if tracing() {
+		defer trace("Pair", p, input).leave(&remain, &err)
+	}
+
 	output.First, remain, err = p.First.Parse(input, verbose)
 	if err != nil {
 		return
@@ -62,6 +82,14 @@ func (p pairParser[I, O1, O2]) String() string {
 	return fmt.Sprintf("[%v, %v]", p.First, p.Second)
 }
 
+func (p pairParser[I, O1, O2]) Kind() Kind {
+	return KindPair
+}
+
+func (p pairParser[I, O1, O2]) Children() []Node {
+	return nodesOf(p.First, p.Second)
+}
+
 type enclosuredParser[I comparable, P, O, S any] struct {
 	Prefix Parser[I, P]
 	Body   Parser[I, O]
@@ -87,6 +115,10 @@ func WithSuffix[I comparable, O, S any](body Parser[I, O], suffix Parser[I, S])
 }
 
 func (d enclosuredParser[I, P, O, S]) Parse(input []I, verbose bool) (output O, remain []I, err error) {
+	if tracing() {
+		defer trace("WithEnclosure", d, input).leave(&remain, &err)
+	}
+
 	_, remain, err = d.Prefix.Parse(input, verbose)
 	if err != nil {
 		return
@@ -104,3 +136,17 @@ func (d enclosuredParser[I, P, O, S]) Parse(input []I, verbose bool) (output O,
 func (d enclosuredParser[I, P, O, S]) String() string {
 	return fmt.Sprintf("%v, %v, %v", d.Prefix, d.Body, d.Suffix)
 }
+
+func (d enclosuredParser[I, P, O, S]) Kind() Kind {
+	return KindWithEnclosure
+}
+
+func (d enclosuredParser[I, P, O, S]) Children() []Node {
+	var ns []Node
+	for _, p := range []any{d.Prefix, d.Body, d.Suffix} {
+		if _, ok := p.(nothing[I]); !ok {
+			ns = append(ns, NodeOf(p))
+		}
+	}
+	return ns
+}
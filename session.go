@@ -0,0 +1,219 @@
+package parcon
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	// sessions is a map of the last element of backing array of the session input to *Session.
+	sessions sync.Map
+
+	// activeSessions is the number of sessions that not closed yet.
+	activeSessions int32
+)
+
+// Session is a context of a single parsing.
+//
+// Parsers can know the position in the whole input, if they parse a part of Session.Input.
+// Session also owns a memo table for Memo, errors that recovered by Recover, and the user state.
+//
+// Session has to be closed after parsing.
+// A Session should not be used from multiple goroutines at the same time.
+type Session[I comparable] struct {
+	input  []I
+	config config
+
+	linesOnce sync.Once
+	lines     []int
+
+	memo      map[memoKey]*memoEntry[I]
+	memoStack []*memoEntry[I]
+	growing   map[int]int
+
+	errors ErrorList
+
+	// state is the user state, and stateID is the unique ID of it.
+	// The ID is used to distinguish states in the memo table, because the state may not be comparable.
+	state       any
+	stateID     int
+	nextStateID int
+
+	steps   int
+	depth   int
+	outputs int
+
+	traceDepth int
+
+	// indents is the stack of indentations of blocks, for Aligned, IndentBlock, and IndentGuard.
+	indents []string
+
+	// tracking is true while Session is tracking the furthest failure, and furthest is the failure.
+	tracking bool
+	furthest failure[I]
+
+	// reachedEnd is true if a parser needed the input after the end of the input.
+	// Stream uses it to know whether the parser needs more input or not.
+	reachedEnd bool
+}
+
+// checkpoint is a snapshot of Session, to rollback changes when parsers backtrack.
+type checkpoint struct {
+	errors  int
+	state   any
+	stateID int
+	outputs int
+}
+
+// NewSession makes a new Session for `input`.
+//
+// Please parse the slice that returned by Input method instead of `input`, because the Session copies `input`.
+// Parsers that parse `input` itself are not a part of the Session, so they can be used at the same time from other goroutines.
+func NewSession[I comparable](input []I, options ...Option) *Session[I] {
+	// The session uses the last element of the backing array as its key, so the backing array has to be owned by the session.
+	// The input has extra capacity, because the backing array of a slice like input[len(input):] is not the same as the input if len(input) == cap(input).
+	owned := make([]I, len(input), len(input)+1)
+	copy(owned, input)
+
+	return newOwnedSession(owned, options)
+}
+
+// newOwnedSession makes a new Session for `input` without copy.
+// The backing array of `input` has to be not reachable from others, and has extra capacity.
+func newOwnedSession[I comparable](input []I, options []Option) *Session[I] {
+	s := &Session[I]{input: input, config: newConfig(options)}
+	s.state = s.config.state
+
+	sessions.Store(sessionKey(s.input), s)
+	atomic.AddInt32(&activeSessions, 1)
+	if s.config.tracer != nil {
+		atomic.AddInt32(&activeTracers, 1)
+	}
+
+	return s
+}
+
+// Input returns the input of this session.
+func (s *Session[I]) Input() []I {
+	return s.input
+}
+
+// Close closes this session.
+func (s *Session[I]) Close() {
+	if _, loaded := sessions.LoadAndDelete(sessionKey(s.input)); loaded {
+		atomic.AddInt32(&activeSessions, -1)
+		if s.config.tracer != nil {
+			atomic.AddInt32(&activeTracers, -1)
+		}
+	}
+}
+
+// Position returns the position of `remain` in the input of this session.
+//
+// The `remain` has to be a part of the input of this session, like the `remain` that returned by Parser.
+func (s *Session[I]) Position(remain []I) Position {
+	offset := cap(s.input) - cap(remain)
+
+	s.linesOnce.Do(func() {
+		s.lines = lineStarts(s.input, s.config.utf8Text)
+	})
+	return positionInLines(s.input, s.lines, offset)
+}
+
+// Errors returns errors that recovered by Recover.
+func (s *Session[I]) Errors() ErrorList {
+	return s.errors
+}
+
+// State returns the current user state.
+func (s *Session[I]) State() any {
+	return s.state
+}
+
+// setState sets the user state with a new ID.
+func (s *Session[I]) setState(state any) {
+	s.nextStateID++
+	s.state = state
+	s.stateID = s.nextStateID
+}
+
+// checkpoint makes a snapshot of the session.
+// It is safe to call this method of nil.
+func (s *Session[I]) checkpoint() checkpoint {
+	if s == nil {
+		return checkpoint{}
+	}
+	return checkpoint{len(s.errors), s.state, s.stateID, s.outputs}
+}
+
+// rollback rollbacks the session to the checkpoint `c`.
+// It is safe to call this method of nil.
+func (s *Session[I]) rollback(c checkpoint) {
+	if s == nil {
+		return
+	}
+	s.errors = s.errors[:c.errors]
+	s.state = c.state
+	s.stateID = c.stateID
+	s.outputs = c.outputs
+}
+
+// involve marks entries that depend on the left recursion `head` as involved.
+func (s *Session[I]) involve(head *memoEntry[I]) {
+	for i := len(s.memoStack) - 1; i >= 0 && s.memoStack[i] != head; i-- {
+		s.memoStack[i].Involved = true
+	}
+}
+
+// sessionKey returns the key of sessions map.
+func sessionKey[I comparable](input []I) *I {
+	if cap(input) == 0 {
+		return nil
+	}
+	return &input[:cap(input)][cap(input)-1]
+}
+
+// sessionOf returns the Session that `input` belongs to.
+// It returns nil if `input` is not a part of any Session.
+func sessionOf[I comparable](input []I) *Session[I] {
+	if atomic.LoadInt32(&activeSessions) == 0 || cap(input) == 0 {
+		return nil
+	}
+	if s, ok := sessions.Load(sessionKey(input)); ok {
+		return s.(*Session[I])
+	}
+	return nil
+}
+
+// reachEnd marks that a parser needed the input after the end of `input`, like TakeWhile that did not find the end of the sequence.
+// Parsers that read input directly have to call it, and newError calls it if the input is empty.
+func reachEnd[I comparable](input []I) {
+	if s := sessionOf(input); s != nil {
+		s.reachedEnd = true
+	}
+}
+
+// pushIndent pushes the indentation of a block.
+// It is safe to call this method of nil.
+func (s *Session[I]) pushIndent(indent string) {
+	if s != nil {
+		s.indents = append(s.indents, indent)
+	}
+}
+
+// popIndent pops the indentation that pushed by pushIndent.
+// It is safe to call this method of nil.
+func (s *Session[I]) popIndent() {
+	if s != nil {
+		s.indents = s.indents[:len(s.indents)-1]
+	}
+}
+
+// currentIndent returns the indentation of the innermost block, or an empty string if there is no block.
+// It is safe to call this method of nil.
+func (s *Session[I]) currentIndent() string {
+	if s == nil || len(s.indents) == 0 {
+		return ""
+	}
+	return s.indents[len(s.indents)-1]
+}
@@ -0,0 +1,66 @@
+package parcon_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/macrat/parcon"
+)
+
+func ExampleSession() {
+	parser := parcon.Sequence(
+		parcon.TagStr("HELLO", "hello"),
+		parcon.OneOfStr("SPACES", " \n"),
+		parcon.TagStr("WORLD", "world"),
+	)
+
+	session := parcon.NewSession([]rune("hello\n  wor"))
+	defer session.Close()
+
+	_, _, err := parser.Parse(session.Input(), true)
+	fmt.Println(err)
+
+	// OUTPUT:
+	// invalid input at 2:3: expected WORLD but got "w"
+}
+
+func ExampleSession_Position() {
+	parser := parcon.WithSpan(parcon.MultiDigits)
+
+	session := parcon.NewSession([]rune("123 456"))
+	defer session.Close()
+
+	output, remain, err := parser.Parse(session.Input()[4:], true)
+	fmt.Printf("output:%#v span:%v remain:%v err:%v\n", string(output.Value), output.Span, session.Position(remain), err)
+
+	// OUTPUT:
+	// output:"456" span:1:5-1:8 remain:1:8 err:<nil>
+}
+
+func Test_sessionDoesNotShareInput(t *testing.T) {
+	input := make([]rune, 3, 10)
+	copy(input, []rune("abc"))
+
+	session := parcon.NewSession(input, parcon.MaxSteps(1))
+	defer session.Close()
+
+	parser := parcon.Memo(parcon.Many(0, parcon.OneOf("ALPHA", []rune("abc"))))
+
+	if _, _, err := parser.Parse(session.Input(), true); err == nil {
+		t.Errorf("expected an error of MaxSteps in the Session")
+	}
+
+	// The original input is not a part of the Session, so it is not limited and can be parsed from other goroutines.
+	done := make(chan error)
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, _, err := parser.Parse(input, true)
+			done <- err
+		}()
+	}
+	for i := 0; i < 2; i++ {
+		if err := <-done; err != nil {
+			t.Errorf("unexpected error of the original input: %v", err)
+		}
+	}
+}
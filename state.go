@@ -0,0 +1,85 @@
+package parcon
+
+import (
+	"fmt"
+)
+
+// WithState sets the initial user state of Session.
+//
+// The user state is a value that parsers can read and update while parsing, like a symbol table.
+// It is rolled back when parsers backtrack, like Or tries the next alternative.
+// So the state should be an immutable value. Please make a new value to update the state, instead of modifying it in place.
+func WithState(state any) Option {
+	return func(c *config) {
+		c.state = state
+	}
+}
+
+// GetState returns the user state of the Session that `input` belongs to.
+// It returns false if `input` is not a part of any Session or the state is not a S.
+//
+// It is useful for a parser that made by ParserFunc.
+func GetState[S any, I comparable](input []I) (state S, ok bool) {
+	if s := sessionOf(input); s != nil {
+		state, ok = s.state.(S)
+	}
+	return
+}
+
+// SetState sets the user state of the Session that `input` belongs to.
+// It returns false if `input` is not a part of any Session.
+func SetState[I comparable, S any](input []I, state S) bool {
+	s := sessionOf(input)
+	if s == nil {
+		return false
+	}
+	s.setState(state)
+	return true
+}
+
+// StateConvertFunc is a function type to convert parsed value with the user state.
+type StateConvertFunc[O1, O2, S any] func(input O1, state S) (output O2, newState S, err error)
+
+type stateConverter[I comparable, O1, O2, S any] struct {
+	Parser Parser[I, O1]
+	Func   StateConvertFunc[O1, O2, S]
+}
+
+// ConvertWithState converts the output of the given `parser` using the user state, and updates the state.
+//
+// The state is not updated if `fn` returns an error.
+// If the parser is not used in a Session, `fn` gets the zero value of S and the new state is discarded.
+func ConvertWithState[I comparable, O1, O2, S any](parser Parser[I, O1], fn StateConvertFunc[O1, O2, S]) Parser[I, O2] {
+	return stateConverter[I, O1, O2, S]{parser, fn}
+}
+
+func (c stateConverter[I, O1, O2, S]) Parse(input []I, verbose bool) (output O2, remain []I, err error) {
+	if tracing() {
+		defer trace("ConvertWithState", c, input).leave(&remain, &err)
+	}
+
+	var o O1
+	o, remain, err = c.Parser.Parse(input, verbose)
+	if err != nil {
+		return
+	}
+
+	state, _ := GetState[S](input)
+	output, state, err = c.Func(o, state)
+	if err == nil {
+		SetState(input, state)
+	}
+	return
+}
+
+func (c stateConverter[I, O1, O2, S]) String() string {
+	return fmt.Sprint(c.Parser)
+}
+
+func (c stateConverter[I, O1, O2, S]) Kind() Kind {
+	return KindConvertWithState
+}
+
+func (c stateConverter[I, O1, O2, S]) Children() []Node {
+	return nodesOf(c.Parser)
+}
@@ -0,0 +1,99 @@
+package parcon_test
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/macrat/parcon"
+)
+
+func ExampleConvertWithState() {
+	name := parcon.Convert(parcon.MultiAlphas, parcon.ToString)
+	semicolon := parcon.TagStr("SEMICOLON", ";")
+
+	// The state is a list of declared variables.
+	declare := parcon.ConvertWithState(
+		parcon.WithPrefix(parcon.TagStr("LET", "let "), name),
+		func(name string, vars []string) (string, []string, error) {
+			// Make a new list instead of modifying the old one, because the old one may be restored.
+			return "declare " + name, append(append([]string(nil), vars...), name), nil
+		},
+	)
+	use := parcon.ConvertWithState(
+		name,
+		func(name string, vars []string) (string, []string, error) {
+			for _, v := range vars {
+				if v == name {
+					return "use " + name, vars, nil
+				}
+			}
+			return "", vars, fmt.Errorf("%s is not declared", name)
+		},
+	)
+
+	// Commit the use of a variable after found a name, to report an undeclared variable as is.
+	parser := parcon.Many(0, parcon.WithSuffix(
+		parcon.Or(declare, parcon.WithPrefix(parcon.Peek(name), parcon.Commit(use))),
+		semicolon,
+	))
+
+	output, err := parcon.ParseString(parser, "let a;let b;a;b;", parcon.WithState([]string{}))
+	fmt.Printf("output:%#v err:%v\n", output, err)
+
+	_, err = parcon.ParseString(parser, "let a;a;b;", parcon.WithState([]string{}))
+	fmt.Printf("err:%v\n", err)
+
+	// OUTPUT:
+	// output:[]string{"declare a", "declare b", "use a", "use b"} err:<nil>
+	// err:b is not declared
+}
+
+func ExampleGetState() {
+	// Count how many times the parser is called.
+	counter := parcon.Func(func(input []rune, verbose bool) (struct{}, []rune, error) {
+		count, _ := parcon.GetState[int](input)
+		parcon.SetState(input, count+1)
+		return struct{}{}, input, nil
+	})
+
+	parser := parcon.Many(0, parcon.WithPrefix(counter, parcon.SingleAlpha))
+
+	session := parcon.NewSession([]rune("abc"), parcon.WithState(0))
+	defer session.Close()
+
+	_, _, err := parser.Parse(session.Input(), true)
+	fmt.Printf("state:%v err:%v\n", session.State(), err)
+
+	// OUTPUT:
+	// state:3 err:<nil>
+}
+
+func ExampleWithState() {
+	push := func(tag string) parcon.Parser[rune, string] {
+		return parcon.ConvertWithState(
+			parcon.TagStr(tag, tag),
+			func(s string, state string) (string, string, error) {
+				return s, state + s, nil
+			},
+		)
+	}
+
+	join := func(ss []string) (string, error) {
+		return strings.Join(ss, ""), nil
+	}
+
+	// The first alternative updates the state, but it is rolled back because the alternative failed.
+	parser := parcon.Or(
+		parcon.Convert(parcon.Sequence(push("a"), push("b")), join),
+		parcon.Convert(parcon.Sequence(push("a"), push("c")), join),
+	)
+
+	session := parcon.NewSession([]rune("ac"), parcon.WithState(""))
+	defer session.Close()
+
+	output, _, err := parser.Parse(session.Input(), true)
+	fmt.Printf("output:%#v state:%#v err:%v\n", output, session.State(), err)
+
+	// OUTPUT:
+	// output:"ac" state:"ac" err:<nil>
+}
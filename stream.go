@@ -0,0 +1,274 @@
+package parcon
+
+import (
+	"errors"
+	"io"
+	"unicode/utf8"
+)
+
+// ErrTooLong is a error when Stream could not parse a value within the maximum buffer size.
+var ErrTooLong = errors.New("parcon: value too long for the buffer")
+
+// Stream parses values from io.Reader one by one, like bufio.Scanner.
+//
+// Stream reads input from the reader only when it needs, and discards input that already parsed.
+// So it can parse huge input like log files in bounded memory.
+//
+// The parser of Stream parses a single value, like the item of Many.
+// Please give the item parser to NewStream instead of Many(0, item), because Stream calls the parser repeatedly until the end of the input.
+// Many(0, item) would need the whole of the input in the buffer.
+//
+// The parser parses the buffered input, and Stream parses the value again with more input if the parser needed the input after the end of the buffer.
+// For example, TakeWhile that reached the end of the buffer, or Tag that found only a part of the tag at the end of the buffer, makes Stream read more input.
+// So a value is parsed as soon as the input of the value arrived, and it is parsed as the same as if the whole input was given.
+//
+// The built-in parsers report it automatically, but parsers made by Func can not.
+// A parser made by Func should be combined with built-in parsers that check the end of the value, like a Tag of a newline.
+//
+// Positions in errors are positions in the whole of the input.
+// Errors that recovered by Recover do not stop Stream, and Err reports them with the error that stopped Stream as an ErrorList, as the same as ParseAll.
+type Stream[I comparable, O any] struct {
+	reader  io.Reader
+	parser  Parser[I, O]
+	decode  func(dst []I, p []byte, eof bool) (elems []I, n int)
+	options []Option
+	verbose bool
+
+	readSize int
+	maxSize  int
+
+	// rawBuf is the buffer to read bytes, and raw is bytes in rawBuf that not decoded yet.
+	rawBuf []byte
+	raw    []byte
+
+	store []I
+	buf   []I
+	eof   bool
+	pos   Position
+
+	value     O
+	span      Span
+	err       error
+	recovered ErrorList
+}
+
+// NewStream makes a new Stream that parses UTF-8 text from `reader` using the given `parser`.
+//
+// The `options` are used for Session that made for each value.
+func NewStream[O any](reader io.Reader, parser Parser[rune, O], options ...Option) *Stream[rune, O] {
+	return newStream(reader, parser, decodeRunes, options)
+}
+
+// NewByteStream makes a new Stream that parses []byte from `reader` using the given `parser`.
+//
+// The `options` are used for Session that made for each value.
+// Please set UTF8Text if the input is UTF-8 encoded text, to know the line and column of values.
+func NewByteStream[O any](reader io.Reader, parser Parser[byte, O], options ...Option) *Stream[byte, O] {
+	return newStream(reader, parser, decodeBytes, options)
+}
+
+func newStream[I comparable, O any](reader io.Reader, parser Parser[I, O], decode func([]I, []byte, bool) ([]I, int), options []Option) *Stream[I, O] {
+	s := &Stream[I, O]{
+		reader:   reader,
+		parser:   parser,
+		decode:   decode,
+		options:  options,
+		verbose:  newConfig(options).verbose,
+		readSize: 4096,
+		maxSize:  64 * 1024,
+	}
+	if lineStarts(s.buf, newConfig(options).utf8Text) != nil {
+		// Text starts at 1:1.
+		s.pos = Position{Line: 1, Column: 1}
+	}
+	return s
+}
+
+// Buffer sets the maximum number of bytes to read at once, and the maximum number of elements to buffer.
+// The default is 4096 bytes and 64 * 1024 elements.
+//
+// Buffer panics if it is called after parsing has started.
+func (s *Stream[I, O]) Buffer(readSize, maxSize int) {
+	if s.store != nil {
+		panic("parcon: Buffer called after Next")
+	}
+	s.readSize = readSize
+	s.maxSize = maxSize
+}
+
+// Next parses the next value, and reports whether it succeed or not.
+// It returns false at the end of the input or an error.
+// Please check Err after Next returned false.
+func (s *Stream[I, O]) Next() bool {
+	if s.err != nil {
+		return false
+	}
+
+	for {
+		if len(s.buf) == 0 && s.eof {
+			return false
+		}
+
+		if len(s.buf) > 0 || s.eof {
+			if ok, done := s.parse(); done {
+				return ok
+			}
+		}
+
+		if len(s.buf) >= s.maxSize {
+			s.err = ErrTooLong
+			return false
+		}
+		if err := s.fill(); err != nil {
+			s.err = err
+			return false
+		}
+	}
+}
+
+// Value returns the value that parsed by the last Next.
+func (s *Stream[I, O]) Value() O {
+	return s.value
+}
+
+// Span returns the Span of the value that parsed by the last Next.
+func (s *Stream[I, O]) Span() Span {
+	return s.span
+}
+
+// Err returns the error that occurred while parsing.
+// It returns nil if the Stream reached at the end of the input without error.
+//
+// If some errors are recovered by Recover, it returns an ErrorList that includes them and the error that occurred.
+func (s *Stream[I, O]) Err() error {
+	switch {
+	case len(s.recovered) == 0:
+		return s.err
+	case s.err == nil:
+		return s.recovered
+	default:
+		return append(s.recovered[:len(s.recovered):len(s.recovered)], s.err)
+	}
+}
+
+// parse parses a value from the buffer.
+// `done` is false if it needs more input to parse.
+func (s *Stream[I, O]) parse() (ok, done bool) {
+	session := NewSession(s.buf, s.options...)
+	defer session.Close()
+
+	input := session.Input()
+	cp := session.checkpoint()
+	output, remain, err := s.parser.Parse(input, false)
+
+	if session.reachedEnd && !isAborted(err) && !s.eof && len(s.buf) < s.maxSize {
+		return false, false
+	}
+
+	if err != nil && s.verbose {
+		session.rollback(cp)
+		_, _, err = s.parser.Parse(input, true)
+		err = session.explain(err, cp, func() {
+			s.parser.Parse(input, true)
+		})
+	}
+	if err == nil && len(remain) == len(input) {
+		// Stream can not go ahead if the parser consumed nothing.
+		err = newError(s.parser, input, s.verbose)
+	}
+
+	// Positions in the Session are relative to the beginning of the buffer.
+	rebase := func(e ErrInvalidInputVerbose[I]) ErrInvalidInputVerbose[I] {
+		if e.Position != nil {
+			pos := s.pos.join(*e.Position)
+			e.Position = &pos
+		}
+		return e
+	}
+	for _, e := range session.errors {
+		s.recovered = append(s.recovered, mapVerboseErrors(e, rebase))
+	}
+	if err != nil {
+		s.err = mapVerboseErrors(err, rebase)
+		return false, true
+	}
+
+	end := s.pos.join(session.Position(remain))
+	s.value = output
+	s.span = Span{s.pos, end}
+	s.pos = end
+	s.buf = s.buf[len(input)-len(remain):]
+
+	return true, true
+}
+
+// fill reads more input from the reader.
+// It calls Read of the reader only once if it returned some data, to parse the data that arrived without waiting for more, as the same as bufio.Scanner.
+func (s *Stream[I, O]) fill() error {
+	size := s.readSize
+	if size < len(s.buf) {
+		size = len(s.buf)
+	}
+
+	// Move the buffered input to the beginning of the store, to discard the input that already parsed.
+	if cap(s.store) < len(s.buf)+size {
+		store := make([]I, len(s.buf), 2*(len(s.buf)+size))
+		copy(store, s.buf)
+		s.store = store
+	} else {
+		s.store = s.store[:copy(s.store[:len(s.buf)], s.buf)]
+	}
+
+	// Move the bytes that not decoded yet to the beginning of rawBuf, and read after them.
+	// The bytes that not decoded yet are an incomplete rune, so rawBuf needs utf8.UTFMax extra bytes at most.
+	if cap(s.rawBuf) < len(s.raw)+size {
+		rawBuf := make([]byte, size+utf8.UTFMax)
+		copy(rawBuf, s.raw)
+		s.rawBuf = rawBuf
+	} else {
+		s.rawBuf = s.rawBuf[:cap(s.rawBuf)]
+		copy(s.rawBuf, s.raw)
+	}
+	raw := s.rawBuf[:len(s.raw)]
+
+	var n int
+	var err error
+	for empty := 0; n == 0 && err == nil; empty++ {
+		if empty >= 100 {
+			return io.ErrNoProgress
+		}
+		n, err = s.reader.Read(s.rawBuf[len(raw) : len(raw)+size])
+	}
+	raw = s.rawBuf[:len(raw)+n]
+	if err == io.EOF {
+		s.eof = true
+	} else if err != nil {
+		return err
+	}
+
+	var consumed int
+	s.store, consumed = s.decode(s.store, raw, s.eof)
+	s.raw = raw[consumed:]
+	s.buf = s.store
+
+	return nil
+}
+
+// decodeRunes decodes UTF-8 bytes into runes, and appends them to `dst`.
+// It leaves incomplete bytes at the end of `p`, unless `eof` is true.
+func decodeRunes(dst []rune, p []byte, eof bool) (elems []rune, n int) {
+	for n < len(p) {
+		if !eof && !utf8.FullRune(p[n:]) {
+			break
+		}
+		c, size := utf8.DecodeRune(p[n:])
+		dst = append(dst, c)
+		n += size
+	}
+	return dst, n
+}
+
+// decodeBytes appends `p` to `dst` as is.
+func decodeBytes(dst []byte, p []byte, eof bool) (elems []byte, n int) {
+	return append(dst, p...), len(p)
+}
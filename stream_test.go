@@ -0,0 +1,156 @@
+package parcon_test
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing/iotest"
+
+	"github.com/macrat/parcon"
+)
+
+func ExampleStream() {
+	input := strings.NewReader("INFO hello\nWARN something wrong\nINFO こんにちは\n")
+
+	logLine := parcon.Pair(
+		parcon.Convert(parcon.MultiAlphas, parcon.ToString),
+		parcon.WithEnclosure(
+			parcon.SingleSpace,
+			parcon.NoneOfStr("MESSAGE", "\n"),
+			parcon.SingleNewline,
+		),
+	)
+
+	stream := parcon.NewStream(input, logLine)
+	stream.Buffer(8, 64)
+
+	for stream.Next() {
+		line := stream.Value()
+		fmt.Printf("%s: [%s] %s\n", stream.Span(), line.First, line.Second)
+	}
+	if err := stream.Err(); err != nil {
+		panic(err)
+	}
+
+	// OUTPUT:
+	// 1:1-2:1: [INFO] hello
+	// 2:1-3:1: [WARN] something wrong
+	// 3:1-4:1: [INFO] こんにちは
+}
+
+func ExampleStream_error() {
+	input := strings.NewReader("1,2,3,4,5,x,7,")
+
+	item := parcon.WithSuffix(parcon.Convert(parcon.MultiDigits, parcon.ToInt), parcon.TagStr("COMMA", ","))
+
+	stream := parcon.NewStream(input, item)
+	stream.Buffer(4, 64)
+
+	for stream.Next() {
+		fmt.Println(stream.Value())
+	}
+	fmt.Println(stream.Err())
+
+	// OUTPUT:
+	// 1
+	// 2
+	// 3
+	// 4
+	// 5
+	// invalid input at 1:11: expected DIGIT but got "x"
+}
+
+func ExampleStream_pipe() {
+	reader, writer := io.Pipe()
+	parsed := make(chan struct{})
+
+	go func() {
+		for _, line := range []string{"hello\n", "world\n"} {
+			writer.Write([]byte(line))
+
+			// Wait until the line is parsed, to show that Stream does not wait for more input.
+			<-parsed
+		}
+		writer.Close()
+	}()
+
+	line := parcon.WithSuffix(parcon.NoneOfStr("LINE", "\n"), parcon.SingleNewline)
+	stream := parcon.NewStream(reader, line)
+
+	for stream.Next() {
+		fmt.Println(stream.Value())
+		parsed <- struct{}{}
+	}
+	fmt.Println(stream.Err())
+
+	// OUTPUT:
+	// hello
+	// world
+	// <nil>
+}
+
+func ExampleStream_lookahead() {
+	// OneByteReader splits the input at every byte, but values are parsed as the same as if the whole input was given.
+	input := iotest.OneByteReader(strings.NewReader("abc;a;12;"))
+
+	parser := parcon.WithSuffix(
+		parcon.Or(parcon.TagStr("ABC", "abc"), parcon.TagStr("A", "a"), parcon.Convert(parcon.MultiDigits, parcon.ToString)),
+		parcon.TagStr("SEMICOLON", ";"),
+	)
+	stream := parcon.NewStream(input, parser)
+
+	for stream.Next() {
+		fmt.Println(stream.Value(), stream.Span())
+	}
+	fmt.Println(stream.Err())
+
+	// OUTPUT:
+	// abc 1:1-1:5
+	// a 1:5-1:7
+	// 12 1:7-1:10
+	// <nil>
+}
+
+func ExampleStream_commit() {
+	input := strings.NewReader("a=1\nb=2\nc=x\n")
+
+	line := parcon.WithSuffix(
+		parcon.Pair(
+			parcon.Convert(parcon.MultiAlphas, parcon.ToString),
+			parcon.WithPrefix(parcon.TagStr("EQUAL", "="), parcon.Commit(parcon.Convert(parcon.MultiDigits, parcon.ToInt))),
+		),
+		parcon.SingleNewline,
+	)
+	stream := parcon.NewStream(input, line)
+
+	for stream.Next() {
+		fmt.Println(stream.Value().First, stream.Value().Second)
+	}
+	fmt.Println(stream.Err())
+
+	// OUTPUT:
+	// a 1
+	// b 2
+	// invalid input at 3:3: expected DIGIT but got "x"
+}
+
+func ExampleStream_recover() {
+	input := strings.NewReader("1\nx\n3\ny\n")
+
+	newline := parcon.TagStr("NEWLINE", "\n")
+	line := parcon.Recover(parcon.WithSuffix(parcon.Convert(parcon.MultiDigits, parcon.ToInt), newline), newline, -1)
+	stream := parcon.NewStream(input, line)
+
+	for stream.Next() {
+		fmt.Println(stream.Value())
+	}
+	fmt.Println(stream.Err())
+
+	// OUTPUT:
+	// 1
+	// -1
+	// 3
+	// -1
+	// invalid input at 2:1: expected DIGIT but got "x"
+	// invalid input at 4:1: expected DIGIT but got "y"
+}
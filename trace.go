@@ -0,0 +1,183 @@
+package parcon
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync/atomic"
+)
+
+// activeTracers is the number of sessions that have a Tracer.
+var activeTracers int32
+
+// TraceEventType is a type of TraceEvent.
+type TraceEventType int
+
+const (
+	// TraceEnter is an event when a parser started to parse.
+	TraceEnter TraceEventType = iota
+
+	// TraceSuccess is an event when a parser succeed.
+	TraceSuccess
+
+	// TraceFailure is an event when a parser failed.
+	TraceFailure
+)
+
+// String returns the name of the event type like "enter".
+func (t TraceEventType) String() string {
+	switch t {
+	case TraceEnter:
+		return "enter"
+	case TraceSuccess:
+		return "success"
+	case TraceFailure:
+		return "failure"
+	default:
+		return fmt.Sprintf("TraceEventType(%d)", int(t))
+	}
+}
+
+// TraceEvent is an event that reported to Tracer.
+type TraceEvent struct {
+	Type TraceEventType
+
+	// Combinator is the kind of the parser, like "Or" or "Sequence".
+	Combinator string
+
+	// Name is the name of the parser, that is the result of String method.
+	Name string
+
+	// Depth is the nesting depth of the parser, that starts from 0.
+	Depth int
+
+	// Start is the position where the parser started.
+	Start Position
+
+	// End is the position where the parser ended. It is set only if the Type is TraceSuccess.
+	End Position
+
+	// Err is the error that the parser returned. It is set only if the Type is TraceFailure.
+	Err error
+}
+
+// Tracer receives events of parsers for debugging.
+type Tracer interface {
+	Trace(event TraceEvent)
+}
+
+// TracerFunc is a function that implements Tracer.
+type TracerFunc func(event TraceEvent)
+
+// Trace calls the function itself.
+func (f TracerFunc) Trace(event TraceEvent) {
+	f(event)
+}
+
+// WithTracer sets a Tracer to receive events of parsers in Session.
+//
+// Tracing makes parsing very slow. Please use it only for debugging.
+func WithTracer(tracer Tracer) Option {
+	return func(c *config) {
+		c.tracer = tracer
+	}
+}
+
+type textTracer struct {
+	w io.Writer
+}
+
+// NewTextTracer makes a Tracer that writes events as an indented text, like below.
+//
+//	enter Or: one of [HELLO] [WORLD] at 1:1
+//	  enter Tag: HELLO at 1:1
+//	  failure Tag: HELLO at 1:1: invalid input
+//	  enter Tag: WORLD at 1:1
+//	  success Tag: WORLD at 1:1-1:6
+//	success Or: one of [HELLO] [WORLD] at 1:1-1:6
+func NewTextTracer(w io.Writer) Tracer {
+	return textTracer{w}
+}
+
+func (t textTracer) Trace(event TraceEvent) {
+	indent := strings.Repeat("  ", event.Depth)
+
+	switch event.Type {
+	case TraceSuccess:
+		fmt.Fprintf(t.w, "%s%v %s: %s at %v\n", indent, event.Type, event.Combinator, event.Name, Span{event.Start, event.End})
+	case TraceFailure:
+		fmt.Fprintf(t.w, "%s%v %s: %s at %v: %v\n", indent, event.Type, event.Combinator, event.Name, event.Start, event.Err)
+	default:
+		fmt.Fprintf(t.w, "%s%v %s: %s at %v\n", indent, event.Type, event.Combinator, event.Name, event.Start)
+	}
+}
+
+// tracePoint is a parser call that is being traced.
+// It is a nil if tracing is disabled.
+type tracePoint[I comparable] struct {
+	session    *Session[I]
+	combinator string
+	name       string
+	start      Position
+}
+
+// tracing reports whether any Session has a Tracer.
+// Parsers check it before calling trace, to skip the defer and the arguments of trace if tracing is disabled, like below.
+//
+//	if tracing() {
+//		defer trace("Or", o, input).leave(&remain, &err)
+//	}
+//
+// This function is called by all parsers, so it has to be cheap enough to be inlined.
+func tracing() bool {
+	return atomic.LoadInt32(&activeTracers) != 0
+}
+
+// trace reports TraceEnter event if the Session of `input` has a Tracer.
+// Please call leave of the result after parsing. It returns nil if the Session does not have a Tracer.
+func trace[I comparable, P any](combinator string, parser P, input []I) *tracePoint[I] {
+	s := sessionOf(input)
+	if s == nil || s.config.tracer == nil {
+		return nil
+	}
+
+	t := &tracePoint[I]{s, combinator, fmt.Sprint(parser), s.Position(input)}
+	s.config.tracer.Trace(TraceEvent{
+		Type:       TraceEnter,
+		Combinator: t.combinator,
+		Name:       t.name,
+		Depth:      s.traceDepth,
+		Start:      t.start,
+	})
+	s.traceDepth++
+
+	return t
+}
+
+// leave reports TraceSuccess or TraceFailure event.
+// It is safe to call this method of nil.
+func (t *tracePoint[I]) leave(remain *[]I, err *error) {
+	if t != nil {
+		t.finish(*remain, *err)
+	}
+}
+
+func (t *tracePoint[I]) finish(remain []I, err error) {
+	s := t.session
+	s.traceDepth--
+
+	event := TraceEvent{
+		Type:       TraceSuccess,
+		Combinator: t.combinator,
+		Name:       t.name,
+		Depth:      s.traceDepth,
+		Start:      t.start,
+	}
+	if err != nil {
+		event.Type = TraceFailure
+		event.Err = err
+	} else {
+		event.End = s.Position(remain)
+	}
+	s.config.tracer.Trace(event)
+}
@@ -0,0 +1,48 @@
+package parcon_test
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/macrat/parcon"
+)
+
+func ExampleNewTextTracer() {
+	parser := parcon.Or(
+		parcon.TagStr("HELLO", "hello"),
+		parcon.TagStr("WORLD", "world"),
+	)
+
+	_, err := parcon.ParseString(parser, "world", parcon.WithTracer(parcon.NewTextTracer(os.Stdout)))
+	fmt.Println(err)
+
+	// OUTPUT:
+	// enter Or: one of [HELLO] [WORLD] at 1:1
+	//   enter Tag: HELLO at 1:1
+	//   failure Tag: HELLO at 1:1: invalid input
+	//   enter Tag: WORLD at 1:1
+	//   success Tag: WORLD at 1:1-1:6
+	// success Or: one of [HELLO] [WORLD] at 1:1-1:6
+	// <nil>
+}
+
+func ExampleTracerFunc() {
+	parser := parcon.Named("GREETING", parcon.Sequence(
+		parcon.TagStr("HELLO", "hello"),
+		parcon.TagStr("WORLD", "world"),
+	))
+
+	// Count events of each combinator.
+	counts := make(map[string]int)
+	tracer := parcon.TracerFunc(func(event parcon.TraceEvent) {
+		if event.Type == parcon.TraceEnter {
+			counts[event.Combinator]++
+		}
+	})
+
+	_, err := parcon.ParseString(parser, "helloworld", parcon.WithTracer(tracer))
+	fmt.Println(counts, err)
+
+	// OUTPUT:
+	// map[Named:1 Sequence:1 Tag:2] <nil>
+}
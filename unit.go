@@ -35,21 +35,18 @@ func TagStr(name string, tag string) Parser[rune, string] {
 }
 
 func (t tagParser[I, O]) Parse(input []I, verbose bool) (output O, remain []I, err error) {
-	if len(t.Tag) > len(input) {
-		if verbose {
-			err = ErrInvalidInputVerbose[I]{Expected: t.Name, Input: input}
-		} else {
-			err = ErrInvalidInput
-		}
-		return
+	if tracing() {
+		defer trace("Tag", t, input).leave(&remain, &err)
 	}
+
 	for i := range t.Tag {
+		if i >= len(input) {
+			reachEnd(input)
+			err = newError(t.Name, input, verbose)
+			return
+		}
 		if t.Tag[i] != input[i] {
-			if verbose {
-				err = ErrInvalidInputVerbose[I]{Expected: t.Name, Input: input}
-			} else {
-				err = ErrInvalidInput
-			}
+			err = newError(t.Name, input, verbose)
 			return
 		}
 	}
@@ -61,6 +58,18 @@ func (t tagParser[I, O]) String() string {
 	return t.Name
 }
 
+func (t tagParser[I, O]) Kind() Kind {
+	return KindTag
+}
+
+func (t tagParser[I, O]) Children() []Node {
+	return nil
+}
+
+func (t tagParser[I, O]) Literal() any {
+	return t.Tag
+}
+
 type oneOfParser[T comparable] struct {
 	Name string
 	List []T
@@ -76,14 +85,14 @@ func OneOf[T comparable](name string, list []T) Parser[T, T] {
 }
 
 func (o oneOfParser[T]) Parse(input []T, verbose bool) (output T, remain []T, err error) {
+	if tracing() {
+		defer trace("OneOf", o, input).leave(&remain, &err)
+	}
+
 	if len(input) > 0 && contains(o.List, input[0]) {
 		return input[0], input[1:], nil
 	} else {
-		if verbose {
-			err = ErrInvalidInputVerbose[T]{Expected: o, Input: input}
-		} else {
-			err = ErrInvalidInput
-		}
+		err = newError(o, input, verbose)
 		return
 	}
 }
@@ -92,6 +101,18 @@ func (o oneOfParser[T]) String() string {
 	return o.Name
 }
 
+func (o oneOfParser[T]) Kind() Kind {
+	return KindOneOf
+}
+
+func (o oneOfParser[T]) Children() []Node {
+	return nil
+}
+
+func (o oneOfParser[T]) Literal() any {
+	return o.List
+}
+
 type oneOfListParser[T comparable] struct {
 	Name string
 	List []T
@@ -112,12 +133,12 @@ func OneOfStr(name string, list string) Parser[rune, string] {
 }
 
 func (o oneOfListParser[T]) Parse(input []T, verbose bool) (output []T, remain []T, err error) {
+	if tracing() {
+		defer trace("OneOfList", o, input).leave(&remain, &err)
+	}
+
 	if len(input) == 0 || !contains(o.List, input[0]) {
-		if verbose {
-			err = ErrInvalidInputVerbose[T]{Expected: o, Input: input}
-		} else {
-			err = ErrInvalidInput
-		}
+		err = newError(o, input, verbose)
 		return
 	}
 
@@ -127,6 +148,9 @@ func (o oneOfListParser[T]) Parse(input []T, verbose bool) (output []T, remain [
 			break
 		}
 	}
+	if i == len(input) {
+		reachEnd(input)
+	}
 	return input[:i], input[i:], nil
 }
 
@@ -134,6 +158,18 @@ func (o oneOfListParser[T]) String() string {
 	return o.Name
 }
 
+func (o oneOfListParser[T]) Kind() Kind {
+	return KindOneOfList
+}
+
+func (o oneOfListParser[T]) Children() []Node {
+	return nil
+}
+
+func (o oneOfListParser[T]) Literal() any {
+	return o.List
+}
+
 type noneOfParser[T comparable] struct {
 	Name string
 	List []T
@@ -147,14 +183,14 @@ func NoneOf[T comparable](name string, list []T) Parser[T, T] {
 }
 
 func (n noneOfParser[T]) Parse(input []T, verbose bool) (output T, remain []T, err error) {
+	if tracing() {
+		defer trace("NoneOf", n, input).leave(&remain, &err)
+	}
+
 	if len(input) > 0 && !contains(n.List, input[0]) {
 		return input[0], input[1:], nil
 	} else {
-		if verbose {
-			err = ErrInvalidInputVerbose[T]{n.Name, input}
-		} else {
-			err = ErrInvalidInput
-		}
+		err = newError(n.Name, input, verbose)
 		return
 	}
 }
@@ -163,6 +199,18 @@ func (n noneOfParser[T]) String() string {
 	return n.Name
 }
 
+func (n noneOfParser[T]) Kind() Kind {
+	return KindNoneOf
+}
+
+func (n noneOfParser[T]) Children() []Node {
+	return nil
+}
+
+func (n noneOfParser[T]) Literal() any {
+	return n.List
+}
+
 type noneOfListParser[T comparable] struct {
 	Name string
 	List []T
@@ -183,12 +231,12 @@ func NoneOfStr(name string, list string) Parser[rune, string] {
 }
 
 func (n noneOfListParser[T]) Parse(input []T, verbose bool) (output []T, remain []T, err error) {
+	if tracing() {
+		defer trace("NoneOfList", n, input).leave(&remain, &err)
+	}
+
 	if len(input) == 0 || contains(n.List, input[0]) {
-		if verbose {
-			err = ErrInvalidInputVerbose[T]{n.Name, input}
-		} else {
-			err = ErrInvalidInput
-		}
+		err = newError(n.Name, input, verbose)
 		return
 	}
 
@@ -198,6 +246,9 @@ func (n noneOfListParser[T]) Parse(input []T, verbose bool) (output []T, remain
 			break
 		}
 	}
+	if i == len(input) {
+		reachEnd(input)
+	}
 	return input[:i], input[i:], nil
 }
 
@@ -205,6 +256,18 @@ func (n noneOfListParser[T]) String() string {
 	return n.Name
 }
 
+func (n noneOfListParser[T]) Kind() Kind {
+	return KindNoneOfList
+}
+
+func (n noneOfListParser[T]) Children() []Node {
+	return nil
+}
+
+func (n noneOfListParser[T]) Literal() any {
+	return n.List
+}
+
 type anything[T comparable] struct{}
 
 // Anything parses any single value.
@@ -213,12 +276,12 @@ func Anything[T comparable]() Parser[T, T] {
 }
 
 func (a anything[T]) Parse(input []T, verbose bool) (output T, remain []T, err error) {
+	if tracing() {
+		defer trace("Anything", a, input).leave(&remain, &err)
+	}
+
 	if len(input) == 0 {
-		if verbose {
-			err = ErrInvalidInputVerbose[T]{"ANYTHING", input}
-		} else {
-			err = ErrInvalidInput
-		}
+		err = newError("ANYTHING", input, verbose)
 		return
 	}
 	return input[0], input[1:], nil
@@ -228,6 +291,14 @@ func (a anything[T]) String() string {
 	return "ANYTHING"
 }
 
+func (a anything[T]) Kind() Kind {
+	return KindAnything
+}
+
+func (a anything[T]) Children() []Node {
+	return nil
+}
+
 type nothing[I comparable] struct{}
 
 // Nothing parses nothing, just leave all of inputs as `remain` and returns `struct{}` as an output.
@@ -243,6 +314,14 @@ func (n nothing[I]) String() string {
 	return "NOTHING"
 }
 
+func (n nothing[I]) Kind() Kind {
+	return KindNothing
+}
+
+func (n nothing[I]) Children() []Node {
+	return nil
+}
+
 type takeSingleParser[I comparable] struct {
 	Name string
 	Func func(I) bool
@@ -255,14 +334,14 @@ func TakeSingle[I comparable](name string, fn func(I) bool) Parser[I, I] {
 }
 
 func (t takeSingleParser[I]) Parse(input []I, verbose bool) (output I, remain []I, err error) {
+	if tracing() {
+		defer trace("TakeSingle", t, input).leave(&remain, &err)
+	}
+
 	if len(input) > 0 && t.Func(input[0]) {
 		return input[0], input[1:], nil
 	} else {
-		if verbose {
-			err = ErrInvalidInputVerbose[I]{t.Name, input}
-		} else {
-			err = ErrInvalidInput
-		}
+		err = newError(t.Name, input, verbose)
 		return
 	}
 }
@@ -271,6 +350,14 @@ func (t takeSingleParser[I]) String() string {
 	return t.Name
 }
 
+func (t takeSingleParser[I]) Kind() Kind {
+	return KindTakeSingle
+}
+
+func (t takeSingleParser[I]) Children() []Node {
+	return nil
+}
+
 type takeWhileParser[I comparable] struct {
 	Name string
 	Func func(I) bool
@@ -283,12 +370,12 @@ func TakeWhile[I comparable](name string, fn func(I) bool) Parser[I, []I] {
 }
 
 func (t takeWhileParser[I]) Parse(input []I, verbose bool) (output []I, remain []I, err error) {
+	if tracing() {
+		defer trace("TakeWhile", t, input).leave(&remain, &err)
+	}
+
 	if len(input) == 0 || !t.Func(input[0]) {
-		if verbose {
-			err = ErrInvalidInputVerbose[I]{t.Name, input}
-		} else {
-			err = ErrInvalidInput
-		}
+		err = newError(t.Name, input, verbose)
 		return
 	}
 
@@ -298,9 +385,20 @@ func (t takeWhileParser[I]) Parse(input []I, verbose bool) (output []I, remain [
 			break
 		}
 	}
+	if i == len(input) {
+		reachEnd(input)
+	}
 	return input[:i], input[i:], nil
 }
 
 func (t takeWhileParser[I]) String() string {
 	return t.Name
 }
+
+func (t takeWhileParser[I]) Kind() Kind {
+	return KindTakeWhile
+}
+
+func (t takeWhileParser[I]) Children() []Node {
+	return nil
+}
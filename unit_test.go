@@ -141,7 +141,7 @@ func ExampleTakeSingle() {
 	// OUTPUT:
 	// output:"a" remain:"bc" err:<nil>
 	// output:"b" remain:"cd" err:<nil>
-	// err:invalid input: expected ABC but got "def"
+	// err:invalid input: expected ABC but got "d"
 }
 
 func ExampleTakeWhile() {